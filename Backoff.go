@@ -0,0 +1,94 @@
+package testcase
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/adamluzsi/testcase/clock"
+)
+
+const (
+	defaultBackoffInterval   = 10 * time.Millisecond
+	defaultBackoffMultiplier = 2
+)
+
+// Backoff is a RetryStrategy, which waits between the attempts with an exponentially growing interval.
+// Compared to Waiter, it gives more time for the system under test to reach the expected state,
+// without the cost of frequent attempts.
+type Backoff struct {
+	// Interval is the time to wait before the first retry.
+	// By default, it is 10 milliseconds.
+	Interval time.Duration
+	// MaxInterval is the upper limit of the time to wait between two attempts.
+	// By default, the interval is not limited.
+	MaxInterval time.Duration
+	// Multiplier is the factor by which the interval grows after each attempt.
+	// By default, it is 2.
+	Multiplier float64
+	// Jitter is the fraction of the interval, between 0 and 1, by which the interval is randomized,
+	// so concurrently retried operations don't hit the system under test in sync.
+	// For example, with 0.1, the interval varies by ±10%.
+	Jitter float64
+	// Timeout is used to calculate the deadline for the Backoff.While call.
+	// If the retry takes longer than the Timeout, the retry will be cancelled.
+	// To limit the number of attempts as well, combine it with RetryCount using Either.
+	Timeout time.Duration
+	// Clock is used to tell the time and to wait between the attempts.
+	// By default, the real time is used.
+	// When the Clock is a *clock.Fake (e.g.: T.Clock), the fake time is advanced instead of waiting.
+	Clock clock.Clock
+}
+
+// While will retry with growing intervals until a condition met, or until the timeout.
+// By default, if the timeout is not defined, it just attempts to execute the condition once.
+// Calling multiple times the condition function should be a safe operation.
+func (b Backoff) While(condition func() bool) {
+	deadline := clockNow(b.Clock).Add(b.Timeout)
+	interval := b.getInterval()
+	for condition() {
+		remaining := deadline.Sub(clockNow(b.Clock))
+		if remaining <= 0 {
+			return
+		}
+		wait := b.jitter(interval)
+		if remaining < wait {
+			wait = remaining
+		}
+		clockSleep(b.Clock, wait)
+		interval = b.next(interval)
+	}
+}
+
+func (b Backoff) getInterval() time.Duration {
+	if b.Interval <= 0 {
+		return b.capped(defaultBackoffInterval)
+	}
+	return b.capped(b.Interval)
+}
+
+func (b Backoff) next(interval time.Duration) time.Duration {
+	multiplier := b.Multiplier
+	if multiplier <= 0 {
+		multiplier = defaultBackoffMultiplier
+	}
+	return b.capped(time.Duration(float64(interval) * multiplier))
+}
+
+func (b Backoff) capped(interval time.Duration) time.Duration {
+	if 0 < b.MaxInterval && b.MaxInterval < interval {
+		return b.MaxInterval
+	}
+	return interval
+}
+
+func (b Backoff) jitter(interval time.Duration) time.Duration {
+	if b.Jitter <= 0 {
+		return interval
+	}
+	jitter := b.Jitter
+	if 1 < jitter {
+		jitter = 1
+	}
+	delta := float64(interval) * jitter
+	return time.Duration(float64(interval) - delta + rand.Float64()*2*delta)
+}
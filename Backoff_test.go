@@ -0,0 +1,68 @@
+package testcase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase"
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/clock"
+)
+
+func TestBackoff_While(t *testing.T) {
+	t.Run(`when the timeout is not defined, the condition is executed once`, func(t *testing.T) {
+		assert.Must(t).Equal(1, countRetryAttempts(testcase.Backoff{}, alwaysRetry))
+	})
+
+	t.Run(`when the condition is met, it stops`, func(t *testing.T) {
+		strategy := testcase.Backoff{Interval: time.Millisecond, Timeout: time.Minute}
+		assert.Must(t).Equal(3, countRetryAttempts(strategy, func(attempt int) bool { return attempt < 3 }))
+	})
+
+	t.Run(`the wait time between the attempts grows exponentially until the timeout`, func(t *testing.T) {
+		var times []time.Time
+		strategy := testcase.Backoff{Interval: 10 * time.Millisecond, Multiplier: 2, Timeout: 100 * time.Millisecond}
+		start := time.Now()
+		countRetryAttempts(strategy, func(int) bool {
+			times = append(times, time.Now())
+			return true
+		})
+		// attempts at 0, 10ms, 30ms, 70ms, and the last one at the timeout
+		assert.Must(t).Equal(5, len(times))
+		for i := 2; i < len(times)-1; i++ {
+			assert.Must(t).True(times[i-1].Sub(times[i-2]) < times[i].Sub(times[i-1]))
+		}
+		assert.Must(t).True(100*time.Millisecond <= time.Since(start))
+		assert.Must(t).True(time.Since(start) < time.Second)
+	})
+
+	t.Run(`when max interval is defined, the wait time is capped`, func(t *testing.T) {
+		strategy := testcase.Backoff{Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond, Multiplier: 10, Timeout: 100 * time.Millisecond}
+		assert.Must(t).True(20 < countRetryAttempts(strategy, alwaysRetry))
+	})
+
+	t.Run(`when jitter is defined, the wait time is randomized around the interval`, func(t *testing.T) {
+		strategy := testcase.Backoff{Interval: 10 * time.Millisecond, MaxInterval: 10 * time.Millisecond, Jitter: 0.5, Timeout: 200 * time.Millisecond}
+		var times []time.Time
+		countRetryAttempts(strategy, func(int) bool {
+			times = append(times, time.Now())
+			return true
+		})
+		for i := 1; i < len(times)-1; i++ {
+			wait := times[i].Sub(times[i-1])
+			assert.Must(t).True(5*time.Millisecond <= wait, wait)
+		}
+	})
+
+	t.Run(`when a fake clock is used, the fake time is advanced instead of waiting`, func(t *testing.T) {
+		c := clock.NewFake(time.Now())
+		c.Freeze()
+		begin := c.Now()
+		strategy := testcase.Backoff{Interval: time.Minute, Timeout: time.Hour, Clock: c}
+		start := time.Now()
+		// attempts at 0, 1m, 3m, 7m, 15m, 31m, and the last one at the timeout
+		assert.Must(t).Equal(7, countRetryAttempts(strategy, alwaysRetry))
+		assert.Must(t).Equal(begin.Add(time.Hour), c.Now())
+		assert.Must(t).True(time.Since(start) < time.Second)
+	})
+}
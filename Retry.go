@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/adamluzsi/testcase/clock"
 	"github.com/adamluzsi/testcase/internal"
 )
 
@@ -30,16 +31,19 @@ func (fn RetryStrategyFunc) While(condition func() bool) { fn(condition) }
 // In case expectations are failed, it will retry the assertion block using the RetryStrategy.
 // The last failed assertion results would be published to the received testing.TB.
 // Calling multiple times the assertion function block content should be a safe and repeatable operation.
+// The number of the current attempt can be retrieved in the assertion block with RetryAttempt.
 func (r Retry) Assert(tb testing.TB, blk func(testing.TB)) {
 	tb.Helper()
 	var lastRecorder *internal.RecorderTB
 
+	var attempt int
 	r.Strategy.While(func() bool {
 		tb.Helper()
+		attempt++
 		lastRecorder = &internal.RecorderTB{TB: tb}
 		internal.RecoverExceptGoexit(func() {
 			tb.Helper()
-			blk(lastRecorder)
+			blk(retryTB{TB: lastRecorder, attempt: attempt})
 		})
 		if lastRecorder.IsFailed {
 			lastRecorder.CleanupNow()
@@ -66,6 +70,172 @@ func RetryCount(times int) RetryStrategy {
 	})
 }
 
+// AttemptRetryStrategy is an attempt-aware RetryStrategy.
+// After each failed attempt, it is called with the number of the attempts made so far,
+// and it decides whether a new attempt should be made, and how long to wait before it.
+// The waits between the attempts are in real time, use AttemptRetryStrategy.WithClock to wait with a fake clock.
+type AttemptRetryStrategy func(attempt int) (wait time.Duration, retry bool)
+
+func (fn AttemptRetryStrategy) While(condition func() bool) {
+	fn.while(nil, condition)
+}
+
+// WithClock returns a RetryStrategy that waits between the attempts with the given Clock.
+// When the Clock is a *clock.Fake (e.g.: T.Clock), the fake time is advanced instead of waiting,
+// the same way as Waiter and Backoff do.
+func (fn AttemptRetryStrategy) WithClock(c clock.Clock) RetryStrategy {
+	return RetryStrategyFunc(func(condition func() bool) { fn.while(c, condition) })
+}
+
+func (fn AttemptRetryStrategy) while(c clock.Clock, condition func() bool) {
+	for attempt := 1; condition(); attempt++ {
+		wait, retry := fn(attempt)
+		if !retry {
+			return
+		}
+		clockSleep(c, wait)
+	}
+}
+
+// RetryAttempt returns the number of the current attempt, starting from 1,
+// when called with the testing.TB of a Retry.Assert assertion block,
+// or with the T of a test marked with Flaky.
+// In a T.Eventually assertion block, it can be called with it.Must.TB.
+// Outside of a retry, it returns zero.
+func RetryAttempt(tb testing.TB) int {
+	for {
+		switch v := tb.(type) {
+		case retryTB:
+			return v.attempt
+		case *T:
+			tb = v.TB
+		default:
+			return 0
+		}
+	}
+}
+
+// retryTB is the testing.TB of a Retry.Assert assertion block.
+type retryTB struct {
+	testing.TB
+	attempt int
+}
+
+// Either combines the retry strategies, and retries as long as each of the strategies allows a new attempt.
+// The retry stops when any of the strategies gives up, thus
+// Either(RetryCount(5), Waiter{WaitTimeout: 10 * time.Second}) retries 5 times at most, but not longer than 10 seconds.
+// Without strategies, the condition is executed only once.
+func Either(strategies ...RetryStrategy) RetryStrategy {
+	return RetryStrategyFunc(func(condition func() bool) {
+		combineRetryStrategies(strategies, condition, func(running int) bool {
+			return running == len(strategies)
+		})
+	})
+}
+
+// Both combines the retry strategies, and retries as long as any of the strategies allows a new attempt.
+// The retry stops when all the strategies gave up, thus
+// Both(RetryCount(5), Waiter{WaitTimeout: 10 * time.Second}) retries at least 5 times, and at least for 10 seconds.
+// Without strategies, the condition is executed only once.
+func Both(strategies ...RetryStrategy) RetryStrategy {
+	return RetryStrategyFunc(func(condition func() bool) {
+		combineRetryStrategies(strategies, condition, func(running int) bool {
+			return 0 < running
+		})
+	})
+}
+
+// combineRetryStrategies executes the retry loop of each strategy in its own goroutine,
+// where a proxy condition asks for an attempt.
+// The condition itself is executed on the caller's goroutine,
+// when the strategies that still run allow it, and its result is shared with them.
+func combineRetryStrategies(strategies []RetryStrategy, condition func() bool, canRetry func(running int) bool) {
+	if len(strategies) == 0 {
+		condition()
+		return
+	}
+	var proxies []*retryProxy
+	for _, strategy := range strategies {
+		proxies = append(proxies, startRetryProxy(strategy))
+	}
+	defer func() {
+		for _, p := range proxies {
+			p.stop()
+		}
+	}()
+	for {
+		var running []*retryProxy
+		for _, p := range proxies {
+			if p.next() {
+				running = append(running, p)
+			}
+		}
+		if !canRetry(len(running)) {
+			return
+		}
+		ok := condition()
+		for _, p := range running {
+			p.answer(ok)
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+type retryProxy struct {
+	attempt  chan struct{}
+	result   chan bool
+	done     chan struct{}
+	waiting  bool
+	finished bool
+}
+
+func startRetryProxy(strategy RetryStrategy) *retryProxy {
+	p := &retryProxy{
+		attempt: make(chan struct{}),
+		result:  make(chan bool),
+		done:    make(chan struct{}),
+	}
+	go func() {
+		defer close(p.done)
+		strategy.While(func() bool {
+			p.attempt <- struct{}{}
+			return <-p.result
+		})
+	}()
+	return p
+}
+
+// next waits until the strategy either asks for a new attempt or gives up.
+func (p *retryProxy) next() bool {
+	if p.finished {
+		return false
+	}
+	select {
+	case <-p.attempt:
+		p.waiting = true
+	case <-p.done:
+		p.finished = true
+	}
+	return p.waiting
+}
+
+func (p *retryProxy) answer(ok bool) {
+	p.waiting = false
+	p.result <- ok
+}
+
+// stop ends the retry loop of the strategy, by reporting the condition as met.
+func (p *retryProxy) stop() {
+	for !p.finished {
+		if p.waiting {
+			p.answer(false)
+		}
+		p.next()
+	}
+}
+
 func makeRetry(i interface{}) (Retry, bool) {
 	switch n := i.(type) {
 	case time.Duration:
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/clock"
 	"github.com/adamluzsi/testcase/fixtures"
 
 	"github.com/adamluzsi/testcase"
@@ -452,3 +453,130 @@ func TestRetryCount_While(t *testing.T) {
 		})
 	})
 }
+
+func countRetryAttempts(strategy testcase.RetryStrategy, condition func(attempt int) bool) int {
+	var attempts int
+	strategy.While(func() bool {
+		attempts++
+		return condition(attempts)
+	})
+	return attempts
+}
+
+func alwaysRetry(int) bool { return true }
+
+func TestEither(t *testing.T) {
+	t.Run(`when the retry count is reached first, it stops`, func(t *testing.T) {
+		strategy := testcase.Either(testcase.RetryCount(3), testcase.Waiter{WaitTimeout: time.Minute})
+		assert.Must(t).Equal(4, countRetryAttempts(strategy, alwaysRetry))
+	})
+
+	t.Run(`when the timeout is reached first, it stops`, func(t *testing.T) {
+		strategy := testcase.Either(testcase.RetryCount(1000000), testcase.Waiter{WaitDuration: time.Millisecond, WaitTimeout: 50 * time.Millisecond})
+		start := time.Now()
+		attempts := countRetryAttempts(strategy, alwaysRetry)
+		assert.Must(t).True(attempts < 1000000)
+		assert.Must(t).True(time.Since(start) < time.Second)
+	})
+
+	t.Run(`when the condition is met, it stops`, func(t *testing.T) {
+		strategy := testcase.Either(testcase.RetryCount(5), testcase.RetryCount(10))
+		assert.Must(t).Equal(2, countRetryAttempts(strategy, func(attempt int) bool { return attempt < 2 }))
+	})
+
+	t.Run(`when no strategy is given, the condition is executed once`, func(t *testing.T) {
+		assert.Must(t).Equal(1, countRetryAttempts(testcase.Either(), alwaysRetry))
+	})
+
+	t.Run(`when the condition panics, the panic is propagated`, func(t *testing.T) {
+		strategy := testcase.Either(testcase.RetryCount(5), testcase.Waiter{WaitTimeout: time.Minute})
+		actualPanicValue := func() (r interface{}) {
+			defer func() { r = recover() }()
+			strategy.While(func() bool { panic(`boom`) })
+			return nil
+		}()
+		assert.Must(t).Equal(`boom`, actualPanicValue)
+	})
+}
+
+func TestBoth(t *testing.T) {
+	t.Run(`it retries until every strategy gives up`, func(t *testing.T) {
+		strategy := testcase.Both(testcase.RetryCount(3), testcase.RetryCount(5), testcase.Waiter{})
+		assert.Must(t).Equal(6, countRetryAttempts(strategy, alwaysRetry))
+	})
+
+	t.Run(`it retries at least until the timeout`, func(t *testing.T) {
+		strategy := testcase.Both(testcase.RetryCount(0), testcase.Waiter{WaitDuration: time.Millisecond, WaitTimeout: 50 * time.Millisecond})
+		start := time.Now()
+		assert.Must(t).True(1 < countRetryAttempts(strategy, alwaysRetry))
+		assert.Must(t).True(50*time.Millisecond <= time.Since(start))
+	})
+
+	t.Run(`when the condition is met, it stops`, func(t *testing.T) {
+		strategy := testcase.Both(testcase.RetryCount(5), testcase.RetryCount(10))
+		assert.Must(t).Equal(3, countRetryAttempts(strategy, func(attempt int) bool { return attempt < 3 }))
+	})
+
+	t.Run(`when no strategy is given, the condition is executed once`, func(t *testing.T) {
+		assert.Must(t).Equal(1, countRetryAttempts(testcase.Both(), alwaysRetry))
+	})
+}
+
+func TestAttemptRetryStrategy_While(t *testing.T) {
+	var attempts []int
+	strategy := testcase.AttemptRetryStrategy(func(attempt int) (time.Duration, bool) {
+		attempts = append(attempts, attempt)
+		return time.Duration(attempt) * time.Millisecond, attempt < 3
+	})
+	start := time.Now()
+	assert.Must(t).Equal(3, countRetryAttempts(strategy, alwaysRetry))
+	assert.Must(t).Equal([]int{1, 2, 3}, attempts)
+	assert.Must(t).True(3*time.Millisecond <= time.Since(start))
+
+	attempts = nil
+	assert.Must(t).Equal(1, countRetryAttempts(strategy, func(int) bool { return false }))
+	assert.Must(t).Equal(0, len(attempts))
+}
+
+func TestAttemptRetryStrategy_WithClock(t *testing.T) {
+	c := clock.NewFake(time.Now())
+	c.Freeze()
+	begin := c.Now()
+	strategy := testcase.AttemptRetryStrategy(func(attempt int) (time.Duration, bool) {
+		return time.Hour, attempt < 3
+	}).WithClock(c)
+	start := time.Now()
+	assert.Must(t).Equal(3, countRetryAttempts(strategy, alwaysRetry))
+	assert.Must(t).Equal(begin.Add(2*time.Hour), c.Now())
+	assert.Must(t).True(time.Since(start) < time.Second)
+}
+
+func TestRetryAttempt(t *testing.T) {
+	t.Run(`in the assertion block of Retry.Assert, it returns the current attempt`, func(t *testing.T) {
+		var attempts []int
+		testcase.Retry{Strategy: testcase.RetryCount(5)}.Assert(t, func(tb testing.TB) {
+			attempts = append(attempts, testcase.RetryAttempt(tb))
+			if len(attempts) < 3 {
+				tb.FailNow()
+			}
+		})
+		assert.Must(t).Equal([]int{1, 2, 3}, attempts)
+	})
+
+	t.Run(`in a test marked with Flaky, it returns the current attempt`, func(t *testing.T) {
+		stub := &internal.StubTB{}
+		s := testcase.NewSpec(stub)
+		var attempts []int
+		s.Test(``, func(t *testcase.T) {
+			attempts = append(attempts, testcase.RetryAttempt(t))
+			t.Must.True(3 <= len(attempts))
+		}, testcase.Flaky(testcase.Backoff{Interval: time.Millisecond, Timeout: time.Minute}))
+		stub.Finish()
+		assert.Must(t).True(!stub.IsFailed)
+		assert.Must(t).Equal([]int{1, 2, 3}, attempts)
+	})
+
+	t.Run(`outside of a retry, it returns zero`, func(t *testing.T) {
+		assert.Must(t).Equal(0, testcase.RetryAttempt(t))
+	})
+}
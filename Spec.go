@@ -8,6 +8,7 @@ import (
 	"runtime/debug"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/adamluzsi/testcase/internal"
 )
@@ -21,8 +22,16 @@ func NewSpec(tb testing.TB, opts ...SpecOption) *Spec {
 		s = tb.spec.newSubSpec("", opts...)
 	default:
 		s = newSpec(tb, opts...)
+		s.rootName = tb.Name()
 		s.seed = getSeed(tb)
-		s.orderer = newOrderer(tb, s.seed)
+		if s.orderer == nil {
+			s.orderer = newOrderer(tb, s.seed)
+		}
+		s.registerFocus()
+		s.registerPackagePath()
+		s.registerReports()
+		tb.Cleanup(s.writeReports)
+		tb.Cleanup(s.saveOrderingHistory)
 		tb.Cleanup(s.Finish)
 	}
 	return s
@@ -35,6 +44,7 @@ func newSpec(tb testing.TB, opts ...SpecOption) *Spec {
 		vars:      newVariables(),
 		immutable: false,
 	}
+	s.location = s.callerLocationName(1)
 	for _, to := range opts {
 		to.setup(s)
 	}
@@ -47,9 +57,12 @@ func (spec *Spec) newSubSpec(desc string, opts ...SpecOption) *Spec {
 	sub := newSpec(spec.testingTB, opts...)
 	sub.parent = spec
 	sub.seed = spec.seed
-	sub.orderer = spec.orderer
+	if sub.orderer == nil {
+		sub.orderer = spec.orderer
+	}
 	sub.description = desc
 	spec.children = append(spec.children, sub)
+	sub.registerFocus()
 	return sub
 }
 
@@ -72,24 +85,58 @@ type Spec struct {
 	children []*Spec
 
 	hooks struct {
-		Around    []hookBlock
+		Around    []hook
 		AroundAll []func() func()
 	}
+	// location is where the spec was declared.
+	location string
 
 	immutable     bool
 	vars          *variables
 	parallel      bool
 	sequential    bool
 	skipBenchmark bool
+	focused       bool
+	hasFocus      bool
 	flaky         *Retry
 	eventually    *Retry
+	timeout       *time.Duration
+	pending       *string
+	property      bool
+	propertyRuns  *int
+	pendingTests  pendingTests
+	selectedTests selectedTests
+	flakyTests    flakyTests
+	origin        *testOrigin
+	result        *testResult
+	reports       *reportedPackage
 	group         *struct{ name string }
 	description   string
+	id            string
 	tags          []string
-	tests         []func()
+	tests         []testCase
 	finished      bool
-	orderer       orderer
+	orderer       Orderer
 	seed          int64
+	pkgPath       string
+
+	reportAllocs bool
+	// parallelBenchmark tells if the benchmark should use testing.B.RunParallel.
+	parallelBenchmark bool
+	// benchmarkTolerance is the allowed regression compared to the benchmark baseline in percent.
+	benchmarkTolerance *float64
+	// benchmarkStats holds the samples of the last benchmark run.
+	benchmarkStats benchmarkStats
+
+	detectGoroutineLeaks bool
+	// ignoredGoroutines are the stack patterns of the goroutines that are not reported as leaked.
+	ignoredGoroutines []*regexp.Regexp
+
+	// reportValues tells if the failure report should contain the values of the variables.
+	reportValues bool
+	// rootName is the name of the root Spec's testing.TB,
+	// which makes the test ids unique between the root specs of the package.
+	rootName string
 }
 
 // Context allow you to create a sub specification for a given spec.
@@ -206,7 +253,7 @@ func (spec *Spec) Sequential() {
 // Skip is equivalent to Log followed by SkipNow on T for each test case.
 func (spec *Spec) Skip(args ...interface{}) {
 	spec.testingTB.Helper()
-	spec.Before(func(t *T) { t.TB.Skip(args...) })
+	spec.Before(func(t *T) { t.Skip(args...) })
 }
 
 // Let define a memoized helper method.
@@ -245,11 +292,25 @@ func (spec *Spec) Let(varName string, blk letBlock) Var {
 		spec.testingTB.Fatalf(warnEventOnImmutableFormat, `Let`)
 	}
 	spec.vars.defs[varName] = blk
+	spec.vars.locations[varName] = spec.callerLocationName(1)
+	spec.vars.scopes[varName] = spec.hookScopeName()
 	return Var{Name: varName, Init: blk}
 }
 
 type letBlock func(t *T) /* T */ interface{}
 
+// lookupLet finds the current definition of the variable, starting from the current scope towards the outer ones.
+func (spec *Spec) lookupLet(varName string) (letBlock, bool) {
+	spec.testingTB.Helper()
+	specs := spec.list()
+	for i := len(specs) - 1; 0 <= i; i-- {
+		if blk, ok := specs[i].vars.defs[varName]; ok && blk != nil {
+			return blk, true
+		}
+	}
+	return nil, false
+}
+
 var acceptedConstKind = map[reflect.Kind]struct{}{
 	reflect.String:     {},
 	reflect.Bool:       {},
@@ -286,6 +347,30 @@ func (spec *Spec) LetValue(varName string, value interface{}) Var {
 	})
 }
 
+// LetShared define a variable, which is initialized once for the current testing context,
+// and shared between all the tests of the context and its sub-contexts.
+// The value is initialized by the first test that uses it, and it is accessible with Var.Get as any other variable.
+// This is ideal for expensive resources like an in-process server or a large dataset.
+//
+// The testing.TB passed to the block reports failures and skips to the test that initialized the value,
+// while its logs belong to the root spec, since the value outlives the test that initialized it.
+// The cleanups registered with it are executed after all tests of the context are finished,
+// including the Parallel ones.
+// The initialization is thread safe, but the value itself is shared between tests,
+// thus it should be either immutable or safe for concurrent use.
+func (spec *Spec) LetShared(varName string, blk func(tb testing.TB) interface{}) Var {
+	spec.testingTB.Helper()
+	sv := &sharedVar{name: varName, init: blk, tb: spec.list()[0].testingTB}
+	v := spec.Let(varName, func(t *T) interface{} { return sv.get(t) })
+	spec.AroundAll(func(tb testing.TB) func() {
+		// the teardown is registered as a cleanup of the testing context,
+		// since the Parallel tests of the context are only finished after the context's test function.
+		tb.Cleanup(sv.close)
+		return func() {}
+	})
+	return v
+}
+
 // Tag allow you to mark tests in the current and below specification scope with tags.
 // This can be used to provide additional documentation about the nature of the testing scope.
 // This later might be used as well to filter your test in your CI/CD pipeline to build separate testing stages like integration, e2e and so on.
@@ -295,10 +380,17 @@ func (spec *Spec) LetValue(varName string, value interface{}) Var {
 //  - TESTCASE_TAG_EXCLUDE to exclude certain test from the overall testing scope.
 // They can be combined as well.
 //
+// Instead of a comma separated list, a tag expression can be used as well,
+// with the && (and), || (or) and ! (not) operators and parentheses.
+// To list the tests that the tag settings select, without executing them,
+// set TESTCASE_TAG_DRY_RUN to true.
+//
 // example usage:
 // 	TESTCASE_TAG_INCLUDE='E2E' go test ./...
 // 	TESTCASE_TAG_EXCLUDE='E2E' go test ./...
 // 	TESTCASE_TAG_INCLUDE='E2E' TESTCASE_TAG_EXCLUDE='list,of,excluded,tags' go test ./...
+// 	TESTCASE_TAG_INCLUDE='(db || cache) && !flaky' go test ./...
+// 	TESTCASE_TAG_INCLUDE='integration && !slow' TESTCASE_TAG_DRY_RUN=true go test ./...
 //
 func (spec *Spec) Tag(tags ...string) {
 	spec.testingTB.Helper()
@@ -308,25 +400,20 @@ func (spec *Spec) Tag(tags ...string) {
 func (spec *Spec) isAllowedToRun() bool {
 	spec.testingTB.Helper()
 	currentTagSet := spec.getTagSet()
-	settings := getCachedTagSettings()
+	settings, err := getCachedTagSettings()
+	if err != nil {
+		spec.testingTB.Fatal(err.Error())
+	}
 
-	for tag := range currentTagSet {
-		if _, ok := settings.Exclude[tag]; ok {
-			return false
-		}
+	if settings.Exclude != nil && settings.Exclude.eval(currentTagSet) {
+		return false
 	}
 
-	if len(settings.Include) == 0 {
+	if settings.Include == nil {
 		return true
 	}
 
-	var allowed bool
-	for tag := range currentTagSet {
-		if _, ok := settings.Include[tag]; ok {
-			allowed = true
-		}
-	}
-	return allowed
+	return settings.Include.eval(currentTagSet)
 }
 
 func (spec *Spec) isBenchAllowedToRun() bool {
@@ -362,18 +449,32 @@ func (spec *Spec) lookupRetryEventually() (Retry, bool) {
 func (spec *Spec) printDescription(t *T) {
 	spec.testingTB.Helper()
 	var lines []interface{}
+	for _, line := range spec.descriptionLines() {
+		lines = append(lines, fmt.Sprintln(line))
+	}
+	log(t, lines...)
+}
+
+// descriptionPath returns the indented description of the spec tree,
+// from the outermost context down to the current spec.
+func (spec *Spec) descriptionPath() string {
+	return strings.Join(spec.descriptionLines(), "\n")
+}
 
-	var spaceIndentLevel int
-	for _, c := range t.contexts() {
+func (spec *Spec) descriptionLines() []string {
+	var (
+		lines            []string
+		spaceIndentLevel int
+	)
+	for _, c := range spec.list() {
 		if c.description == `` {
 			continue
 		}
 
-		lines = append(lines, fmt.Sprintln(strings.Repeat(` `, spaceIndentLevel*2), c.description))
+		lines = append(lines, strings.Repeat(` `, spaceIndentLevel*2)+` `+c.description)
 		spaceIndentLevel++
 	}
-
-	log(t, lines...)
+	return lines
 }
 
 // TODO: add group name representation here
@@ -395,14 +496,38 @@ func (spec *Spec) name() string {
 	return name
 }
 
+// testID returns an identifier for the test that is stable between test runs,
+// as long the specification itself doesn't change.
+// The id starts with the name of the root Spec's testing.TB,
+// thus tests with the same description under different Test functions have different ids.
+func (spec *Spec) testID() string {
+	parts := []string{spec.list()[0].rootName}
+	for _, c := range spec.list() {
+		if c.description != `` {
+			parts = append(parts, c.description)
+		}
+	}
+	if spec.description == `` && spec.parent != nil {
+		parts = append(parts, fmt.Sprintf(`#%d`, len(spec.parent.children)-1))
+	}
+	return strings.Join(parts, `/`)
+}
+
 ///////////////////////////////////////////////////////=- run -=////////////////////////////////////////////////////////
 
 func (spec *Spec) run(blk func(*T)) {
 	spec.testingTB.Helper()
+	spec.result = newTestResult()
 	if !spec.isAllowedToRun() {
 		return
 	}
+	if isTagDryRun() {
+		spec.list()[0].selectedTests.add(spec.descriptionPathLine(), spec.getTagSet())
+		return
+	}
 	name := spec.name()
+	spec.id = spec.testID()
+	spec.registerOrigin()
 	switch tb := spec.testingTB.(type) {
 	case tRunner:
 		spec.addTest(func() {
@@ -420,6 +545,7 @@ func (spec *Spec) run(blk func(*T)) {
 				b.Helper()
 				spec.runB(b, blk)
 			})
+			spec.checkBenchmarkBaseline(spec.testingTB)
 		})
 	case TBRunner:
 		spec.addTest(func() {
@@ -439,34 +565,75 @@ func (spec *Spec) run(blk func(*T)) {
 func (spec *Spec) runTB(tb testing.TB, blk func(*T)) {
 	spec.testingTB.Helper()
 	tb.Helper()
+	begin := time.Now()
+	defer func() { spec.result.record(tb, begin) }()
+	defer spec.logReproduction(tb)
+	spec.skipPending(tb)
+	if spec.isSkippedByFocus() {
+		spec.skip(tb, focusSkipMessage)
+	}
+	if !spec.isInShard() {
+		spec.skip(tb, shardSkipMessage)
+	}
 	if tb, ok := tb.(interface{ Parallel() }); ok && spec.isParallel() {
 		tb.Parallel()
+		begin = time.Now()
 	}
 
 	spec.printDescription(newT(tb, spec))
 
-	test := func(tb testing.TB) {
+	if o, ok := spec.orderer.(testObserver); ok {
+		begin := time.Now()
+		defer func() { o.observe(spec.id, time.Since(begin), tb.Failed()) }()
+	}
+
+	runTest := func(tb testing.TB, gen *Gen, results ...*testResult) {
 		tb.Helper()
-		defer spec.recoverFromPanic(tb)
 		t := newT(tb, spec)
-		defer t.setUp()()
-		blk(t)
+		t.gen = gen
+		t.results = results
+		spec.runWithTimeout(t, func() {
+			defer spec.logFailureReport(t)
+			defer spec.recoverFromPanic(t, t)
+			defer t.setUp()()
+			t.phase.set(`test block`)
+			blk(t)
+		})
+	}
+
+	if spec.property {
+		spec.checkProperty(tb, func(tb testing.TB, gen *Gen, result *testResult) { runTest(tb, gen, result) })
+		return
 	}
 
 	retryHandler, ok := spec.lookupRetryFlaky()
 	if ok {
-		retryHandler.Assert(tb, test)
+		spec.runFlaky(tb, retryHandler, func(tb testing.TB, attempt *testResult) { runTest(tb, nil, spec.result, attempt) })
 	} else {
-		test(tb)
+		runTest(tb, nil, spec.result)
 	}
 }
 
-func (spec *Spec) recoverFromPanic(tb testing.TB) {
+// skip skips the test before the T of the test is made, thus the reason is recorded for the test result here.
+func (spec *Spec) skip(tb testing.TB, reason string) {
+	spec.testingTB.Helper()
+	tb.Helper()
+	spec.result.setSkipReason(reason)
+	tb.Skip(reason)
+}
+
+// recoverFromPanic reports the panic of the test.
+// When the panic comes from a hook, the hook's declaration is reported as the panic location.
+func (spec *Spec) recoverFromPanic(tb testing.TB, t *T) {
 	spec.testingTB.Helper()
 	tb.Helper()
 	if r := recover(); r != nil {
-		_, file, line, _ := runtime.Caller(2)
-		tb.Error(r, fmt.Sprintf(`%s:%d`, file, line), "\n", string(debug.Stack()))
+		location := t.phase.hookLocation()
+		if location == `` {
+			_, file, line, _ := runtime.Caller(2)
+			location = fmt.Sprintf(`%s:%d`, file, line)
+		}
+		tb.Errorf("%v (%s)\n%s", r, location, debug.Stack())
 	}
 }
 
@@ -474,20 +641,27 @@ func (spec *Spec) runB(b *testing.B, blk func(*T)) {
 	spec.testingTB.Helper()
 	b.Helper()
 	t := newT(b, spec)
+	defer spec.result.record(b, time.Now())
+	spec.skipPending(b)
+	if spec.isSkippedByFocus() {
+		b.Skip(focusSkipMessage)
+	}
+	if !spec.isInShard() {
+		b.Skip(shardSkipMessage)
+	}
 	if _, ok := spec.lookupRetryFlaky(); ok {
 		b.Skip(`skipping because retry`)
 	}
+	t.gen = spec.benchmarkGen()
+	if spec.isReportingAllocs() {
+		b.ReportAllocs()
+	}
 
-	for i := 0; i < b.N; i++ {
-		func() {
-			b.StopTimer()
-			b.Helper()
-			defer t.setUp()()
-			b.StartTimer()
-			blk(t)
-			b.StopTimer()
-		}()
+	if spec.isParallelBenchmark() {
+		spec.benchmarkParallel(b, blk)
+		return
 	}
+	spec.benchmark(b, t, blk)
 }
 
 func (spec *Spec) acceptVisitor(v visitor) {
@@ -504,7 +678,8 @@ func (spec *Spec) acceptVisitor(v visitor) {
 // and resource closed with a deferred function, but the spec is still not ran.
 func (spec *Spec) Finish() {
 	spec.testingTB.Helper()
-	var tests []func()
+	spec.checkFocusForbidden()
+	var tests []testCase
 	var hooks []func() func()
 	spec.acceptVisitor(visitorFunc(func(s *Spec) {
 		if s.finished {
@@ -515,15 +690,17 @@ func (spec *Spec) Finish() {
 		tests = append(tests, s.tests...)
 		hooks = append(hooks, s.hooks.AroundAll...)
 	}))
-	spec.orderer.Order(tests)
 	td := &internal.Teardown{}
 	defer td.Finish()
 	for _, hook := range hooks {
 		td.Defer(hook())
 	}
-	for _, tc := range tests {
+	for _, tc := range orderTestCases(spec.orderer, tests) {
 		tc()
 	}
+	spec.printPendingSummary()
+	spec.printTagDryRunSummary()
+	spec.printFlakySummary()
 }
 
 func (spec *Spec) withFinishUsingTestingTB(tb testing.TB, blk func()) {
@@ -586,7 +763,7 @@ func (spec *Spec) getTagSet() map[string]struct{} {
 
 func (spec *Spec) addTest(blk func()) {
 	spec.testingTB.Helper()
-	spec.tests = append(spec.tests, blk)
+	spec.tests = append(spec.tests, testCase{id: spec.id, run: blk})
 }
 
 var escapeNameRGX = regexp.MustCompile(`\\.`)
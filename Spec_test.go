@@ -1,13 +1,16 @@
 package testcase_test
 
 import (
+	"fmt"
 	"math/rand"
+	"path/filepath"
 	"reflect"
 	"runtime"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -641,6 +644,111 @@ func TestSpec_LetValue_mutableValuesAreNotAllowed(t *testing.T) {
 	assert.Must(t).True(stub.IsFailed)
 }
 
+func TestSpec_LetShared(t *testing.T) {
+	var inits, closes int
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	v := s.LetShared(`shared`, func(tb testing.TB) interface{} {
+		inits++
+		tb.Cleanup(func() { closes++ })
+		return &struct{ ID int }{ID: inits}
+	})
+	var values []interface{}
+	s.Context(`sub context`, func(s *testcase.Spec) {
+		for i := 0; i < 3; i++ {
+			s.Test(strconv.Itoa(i), func(t *testcase.T) {
+				t.Must.Equal(0, closes)
+				values = append(values, v.Get(t))
+			})
+		}
+	})
+	stub.Finish()
+	assert.Must(t).True(!stub.IsFailed)
+	assert.Must(t).Equal(1, inits)
+	assert.Must(t).Equal(1, closes)
+	assert.Must(t).Equal(3, len(values))
+	for _, value := range values {
+		assert.Must(t).True(values[0] == value, `the same value was expected for every test`)
+	}
+}
+
+func TestSpec_LetShared_parallel(t *testing.T) {
+	var inits, closes, gets int32
+	t.Run(`spec`, func(t *testing.T) {
+		s := testcase.NewSpec(t)
+		s.Parallel()
+		v := testcase.VarOf[*int32]{Name: `shared`}.LetShared(s, func(tb testing.TB) *int32 {
+			atomic.AddInt32(&inits, 1)
+			tb.Cleanup(func() { atomic.AddInt32(&closes, 1) })
+			return &gets
+		})
+		for i := 0; i < 10; i++ {
+			s.Test(strconv.Itoa(i), func(t *testcase.T) {
+				t.Must.Equal(int32(0), atomic.LoadInt32(&closes))
+				atomic.AddInt32(v.Get(t), 1)
+			})
+		}
+		s.Finish()
+	})
+	assert.Must(t).Equal(int32(1), atomic.LoadInt32(&inits))
+	assert.Must(t).Equal(int32(1), atomic.LoadInt32(&closes))
+	assert.Must(t).Equal(int32(10), atomic.LoadInt32(&gets))
+}
+
+func TestSpec_LetShared_failedInitialization(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	s.Sequential()
+	v := s.LetShared(`shared`, func(tb testing.TB) interface{} {
+		tb.FailNow()
+		return nil
+	})
+	s.Test(``, func(t *testcase.T) { _ = v.Get(t) })
+	stub.Finish()
+	assert.Must(t).True(stub.IsFailed)
+	assert.Must(t).Contain(strings.Join(stub.Logs, "\n"), `shared variable shared failed to initialize`)
+}
+
+func TestSpec_LetShared_testingTBReportsToTheInitializingTest(t *testing.T) {
+	var skipped []bool
+	t.Run(`spec`, func(t *testing.T) {
+		s := testcase.NewSpec(t)
+		s.Sequential()
+		v := s.LetShared(`shared`, func(tb testing.TB) interface{} {
+			tb.Skip(`skipped from the shared variable's initialization`)
+			return nil
+		})
+		for i := 0; i < 2; i++ {
+			s.Test(strconv.Itoa(i), func(t *testcase.T) {
+				t.Cleanup(func() { skipped = append(skipped, t.Skipped()) })
+				_ = v.Get(t)
+			})
+		}
+		s.Finish()
+	})
+	assert.Must(t).Equal([]bool{true, true}, skipped)
+}
+
+func TestSpec_LetShared_testingTBOutlivesTheInitializingTest(t *testing.T) {
+	var rootName string
+	var names []string
+	t.Run(`spec`, func(t *testing.T) {
+		rootName = t.Name()
+		s := testcase.NewSpec(t)
+		s.Sequential()
+		v := s.LetShared(`shared`, func(tb testing.TB) interface{} { return tb })
+		for i := 0; i < 3; i++ {
+			s.Test(strconv.Itoa(i), func(t *testcase.T) {
+				tb := v.Get(t).(testing.TB)
+				names = append(names, tb.Name())
+				tb.Log(`the shared testing.TB is usable after the initializing test is finished`)
+			})
+		}
+		s.Finish()
+	})
+	assert.Must(t).Equal([]string{rootName, rootName, rootName}, names)
+}
+
 func TestSpec_Cleanup_inACleanupWithinACleanup(t *testing.T) {
 	t.Run(`spike`, func(t *testing.T) {
 		var ran bool
@@ -1171,6 +1279,108 @@ func TestSpec_Test_flakyByRetryCount_willRunAgainWithinTheAcceptedRetryCount(t *
 	}, testcase.Flaky(42))
 }
 
+func TestSpec_Test_timeout_hangingTestFailsWithDescriptionAndGoroutineDump(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	release := make(chan struct{})
+	defer close(release)
+
+	s.Describe(`subject`, func(s *testcase.Spec) {
+		s.When(`condition`, func(s *testcase.Spec) {
+			s.Then(`it hangs`, func(t *testcase.T) { <-release })
+		})
+	}, testcase.Timeout(time.Millisecond))
+
+	stub.Finish()
+	assert.Must(t).True(stub.IsFailed)
+	logs := strings.Join(stub.Logs, "\n")
+	assert.Must(t).Contain(logs, `test timed out after 1ms during test block`)
+	assert.Must(t).Contain(logs, `describe subject`)
+	assert.Must(t).Contain(logs, `when condition`)
+	assert.Must(t).Contain(logs, `then it hangs`)
+	assert.Must(t).Contain(logs, `goroutine `)
+}
+
+func TestSpec_Test_timeout_hangingHookIsReported(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	release := make(chan struct{})
+	defer close(release)
+
+	var ran bool
+	s.When(`stuck before`, func(s *testcase.Spec) {
+		s.Before(func(t *testcase.T) { <-release })
+		s.Test(``, func(t *testcase.T) { ran = true }, testcase.Timeout(time.Millisecond))
+	})
+
+	stub.Finish()
+	assert.Must(t).True(stub.IsFailed)
+	assert.Must(t).True(!ran)
+	assert.Must(t).Contain(strings.Join(stub.Logs, "\n"), `during a hook of "when stuck before"`)
+}
+
+func TestSpec_Test_timeout_failNowInTheTestBlock(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+
+	var cleanedUp bool
+	s.Test(``, func(t *testcase.T) {
+		t.Defer(func() { cleanedUp = true })
+		t.FailNow()
+	}, testcase.Timeout(time.Minute))
+
+	stub.Finish()
+	assert.Must(t).True(stub.IsFailed)
+	assert.Must(t).True(cleanedUp)
+	assert.Must(t).NotContain(strings.Join(stub.Logs, "\n"), `test timed out`)
+}
+
+func TestSpec_Test_timeout_testWithinTheTimeoutPasses(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+
+	var ran bool
+	s.Test(``, func(t *testcase.T) { ran = true }, testcase.Timeout(time.Minute))
+
+	stub.Finish()
+	assert.Must(t).True(ran)
+	assert.Must(t).True(!stub.IsFailed)
+}
+
+func TestSpec_Test_timeout_closestScopeOverridesTheOuterTimeout(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub, testcase.Timeout(time.Millisecond))
+
+	var ran bool
+	s.Test(``, func(t *testcase.T) {
+		time.Sleep(10 * time.Millisecond)
+		ran = true
+	}, testcase.Timeout(time.Minute))
+
+	stub.Finish()
+	assert.Must(t).True(ran)
+	assert.Must(t).True(!stub.IsFailed)
+}
+
+func TestSpec_Test_timeout_withFlaky_eachAttemptHasItsOwnTimeout(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	var attempts int32
+	s.Test(``, func(t *testcase.T) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			<-release
+		}
+	}, testcase.Flaky(1), testcase.Timeout(10*time.Millisecond))
+
+	stub.Finish()
+	assert.Must(t).True(!stub.IsFailed)
+	assert.Must(t).Equal(int32(2), atomic.LoadInt32(&attempts))
+}
+
 // This testCase will artificially create a scenario where one of the before block will be held up,
 // and the other testCase is expected to finishNow ahead of time.
 // If the preparation is not done concurrently as well,
@@ -1429,3 +1639,362 @@ func TestSpec_Test_testingTBNoTBRunner_ordered(t *testing.T) {
 
 	assert.Must(t).Equal([]int{3, 4, 5, 1, 2}, out)
 }
+
+func TestSpec_OrderWith(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub, testcase.OrderWith(testcase.ReverseOrderer()))
+
+	var out []int
+	s.Test(``, func(t *testcase.T) { out = append(out, 1) })
+	s.Test(``, func(t *testcase.T) { out = append(out, 2) })
+	s.Test(``, func(t *testcase.T) { out = append(out, 3) })
+	stub.Finish()
+
+	assert.Must(t).Equal([]int{3, 2, 1}, out)
+}
+
+func TestSpec_OrderWith_failedFirstUsesThePreviousTestRun(t *testing.T) {
+	internal.SetupCacheFlush(t)
+	historyPath := filepath.Join(t.TempDir(), `history.json`)
+
+	run := func(failing string) []string {
+		internal.CacheFlush()
+		stub := &internal.StubTB{StubName: t.Name()}
+		s := testcase.NewSpec(stub, testcase.OrderWith(testcase.FailedFirstOrderer(historyPath)))
+		var out []string
+		for _, name := range []string{`a`, `b`, `c`} {
+			name := name
+			s.Test(name, func(t *testcase.T) {
+				out = append(out, name)
+				if name == failing {
+					t.FailNow()
+				}
+			})
+		}
+		stub.Finish()
+		return out
+	}
+
+	assert.Must(t).Equal([]string{`a`, `b`, `c`}, run(`c`))
+	assert.Must(t).Equal([]string{`c`, `a`, `b`}, run(``))
+	assert.Must(t).Equal([]string{`a`, `b`, `c`}, run(``))
+}
+
+func TestSpec_Focus(t *testing.T) {
+	testcase.UnsetEnv(t, testcase.EnvKeyFocusForbidden)
+	stub := &internal.StubTB{}
+	rtb := &internal.RecorderTB{TB: stub}
+	s := testcase.NewSpec(rtb)
+
+	var out []string
+	s.Test(`a`, func(t *testcase.T) { out = append(out, `a`) })
+	s.When(`focused`, func(s *testcase.Spec) {
+		s.Focus()
+		s.Test(`b`, func(t *testcase.T) { out = append(out, `b`) })
+		s.Test(`c`, func(t *testcase.T) { out = append(out, `c`) })
+	})
+	s.Test(`d`, func(t *testcase.T) { out = append(out, `d`) }, testcase.Focus())
+	s.Test(`e`, func(t *testcase.T) { out = append(out, `e`) })
+	rtb.CleanupNow()
+
+	assert.Must(t).True(!rtb.IsFailed)
+	assert.Must(t).ContainExactly([]string{`b`, `c`, `d`}, out)
+}
+
+func TestSpec_Focus_withoutFocusedSpecEveryTestRuns(t *testing.T) {
+	testcase.UnsetEnv(t, testcase.EnvKeyFocusForbidden)
+	stub := &internal.StubTB{}
+	rtb := &internal.RecorderTB{TB: stub}
+	s := testcase.NewSpec(rtb)
+
+	var out []string
+	s.Test(`a`, func(t *testcase.T) { out = append(out, `a`) })
+	s.Test(`b`, func(t *testcase.T) { out = append(out, `b`) })
+	rtb.CleanupNow()
+
+	assert.Must(t).ContainExactly([]string{`a`, `b`}, out)
+}
+
+func TestSpec_Focus_unfocusedTestsAreReportedAsSkipped(t *testing.T) {
+	testcase.UnsetEnv(t, testcase.EnvKeyFocusForbidden)
+	stub := &internal.StubTB{}
+	rtb := &internal.RecorderTB{TB: stub}
+	s := testcase.NewSpec(rtb)
+	s.Test(`focused`, func(t *testcase.T) {}, testcase.Focus())
+	s.Test(`not focused`, func(t *testcase.T) { t.Fail() })
+	rtb.CleanupNow()
+
+	assert.Must(t).True(stub.IsSkipped)
+	assert.Must(t).True(!rtb.IsFailed)
+}
+
+func TestSpec_Focus_whenFocusIsForbidden(t *testing.T) {
+	testcase.SetEnv(t, testcase.EnvKeyFocusForbidden, `true`)
+	stub := &internal.StubTB{}
+	rtb := &internal.RecorderTB{TB: stub}
+	s := testcase.NewSpec(rtb)
+
+	var out []string
+	s.Test(`a`, func(t *testcase.T) { out = append(out, `a`) })
+	s.Test(`b`, func(t *testcase.T) { out = append(out, `b`) }, testcase.Focus())
+	rtb.CleanupNow()
+
+	assert.Must(t).True(rtb.IsFailed, `focus should fail the specification`)
+	assert.Must(t).ContainExactly([]string{`a`, `b`}, out)
+	rtb.Forward()
+	assert.Must(t).Contain(strings.Join(stub.Logs, "\n"), testcase.EnvKeyFocusForbidden)
+}
+
+func TestSpec_Focus_afterTestingGroupAlreadyRanYieldFatal(t *testing.T) {
+	testcase.UnsetEnv(t, testcase.EnvKeyFocusForbidden)
+	stub := &internal.StubTB{}
+	rtb := &internal.RecorderTB{TB: stub}
+	s := testcase.NewSpec(rtb)
+
+	var out []string
+	s.Context(`group`, func(s *testcase.Spec) {
+		s.Test(`a`, func(t *testcase.T) { out = append(out, `a`) })
+	}, testcase.Group(`group`))
+	assert.Must(t).Equal([]string{`a`}, out, `group tests run when the group block is finished`)
+
+	internal.RecoverExceptGoexit(func() {
+		s.Test(`b`, func(t *testcase.T) { out = append(out, `b`) }, testcase.Focus())
+	})
+	rtb.CleanupNow()
+
+	assert.Must(t).True(rtb.IsFailed, `focus after an already run group should fail the specification`)
+	internal.RecoverExceptGoexit(rtb.Forward)
+	assert.Must(t).Contain(strings.Join(stub.Logs, "\n"), `Focus is used after testing groups that already ran`)
+}
+
+func TestSpec_Focus_beforeTestingGroup(t *testing.T) {
+	testcase.UnsetEnv(t, testcase.EnvKeyFocusForbidden)
+	stub := &internal.StubTB{}
+	rtb := &internal.RecorderTB{TB: stub}
+	s := testcase.NewSpec(rtb)
+
+	var out []string
+	s.Test(`a`, func(t *testcase.T) { out = append(out, `a`) }, testcase.Focus())
+	s.Context(`group`, func(s *testcase.Spec) {
+		s.Test(`b`, func(t *testcase.T) { out = append(out, `b`) })
+	}, testcase.Group(`group`))
+	rtb.CleanupNow()
+
+	assert.Must(t).True(!rtb.IsFailed)
+	assert.Must(t).ContainExactly([]string{`a`}, out)
+}
+
+func TestSpec_Focus_callingItAfterContextDeclarationYieldFatal(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	s.Test(``, func(t *testcase.T) {})
+	willFatalWithMessage := willFatalWithMessageFn(stub)
+	assert.Must(t).Contain(willFatalWithMessage(t, func() { s.Focus() }),
+		"you can't use .Focus after you already used when/and/then")
+}
+
+func TestSpec_Pending(t *testing.T) {
+	stub := &internal.StubTB{}
+	rtb := &internal.RecorderTB{TB: stub}
+	s := testcase.NewSpec(rtb)
+
+	var (
+		out         []string
+		letUsed     bool
+		beforeUsed  bool
+		pendingDesc = `not implemented yet`
+	)
+	s.Test(`a`, func(t *testcase.T) { out = append(out, `a`) })
+	s.When(`pending`, func(s *testcase.Spec) {
+		s.Pending(pendingDesc)
+		v := s.Let(`v`, func(t *testcase.T) interface{} { letUsed = true; return 42 })
+		s.Before(func(t *testcase.T) { beforeUsed = true })
+		s.Test(`b`, func(t *testcase.T) { out = append(out, `b`); _ = v.Get(t) })
+	})
+	s.Test(`c`, func(t *testcase.T) { out = append(out, `c`) }, testcase.Pending(`flaky dependency`))
+	s.Todo(`d`)
+	rtb.CleanupNow()
+	rtb.Forward()
+
+	assert.Must(t).True(!rtb.IsFailed)
+	assert.Must(t).True(stub.IsSkipped)
+	assert.Must(t).True(!letUsed)
+	assert.Must(t).True(!beforeUsed)
+	assert.Must(t).Equal([]string{`a`}, out)
+
+	logs := strings.Join(stub.Logs, "\n")
+	assert.Must(t).Contain(logs, `3 pending test(s)`)
+	assert.Must(t).Contain(logs, `when pending b: not implemented yet`)
+	assert.Must(t).Contain(logs, `c: flaky dependency`)
+	assert.Must(t).Contain(logs, `then d: TODO`)
+}
+
+func TestSpec_Pending_callingItAfterContextDeclarationYieldFatal(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	s.Test(``, func(t *testcase.T) {})
+	willFatalWithMessage := willFatalWithMessageFn(stub)
+	assert.Must(t).Contain(willFatalWithMessage(t, func() { s.Pending(`reason`) }),
+		"you can't use .Pending after you already used when/and/then")
+}
+
+type tableTestRow struct {
+	Name     string
+	Input    []int `testcase:"input"`
+	Expected int   `testcase:"expected"`
+}
+
+type tableTestStringerRow struct{ value int }
+
+func (r tableTestStringerRow) String() string { return fmt.Sprintf(`value is %d`, r.value) }
+
+func TestSpec_Table(t *testing.T) {
+	s := testcase.NewSpec(t)
+	s.Sequential()
+
+	var (
+		mutex sync.Mutex
+		names []string
+	)
+	addName := func(t *testcase.T) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		names = append(names, t.Name())
+	}
+
+	s.Describe(`#Sum`, func(s *testcase.Spec) {
+		rows := []tableTestRow{
+			{Name: `empty`, Input: []int{}, Expected: 0},
+			{Name: `positive`, Input: []int{1, 2, 3}, Expected: 6},
+			{Input: []int{-1, -2}, Expected: -3},
+		}
+		s.Table(`table`, rows, func(s *testcase.Spec, row interface{}) {
+			s.Test(`sum`, func(t *testcase.T) {
+				addName(t)
+				var total int
+				for _, n := range t.I(`input`).([]int) {
+					total += n
+				}
+				assert.Must(t).Equal(t.I(`expected`).(int), total)
+			})
+			s.Test(`mutation is isolated`, func(t *testcase.T) {
+				input := t.I(`input`).([]int)
+				for i := range input {
+					input[i] = 42
+				}
+			})
+			s.Test(`row values are not affected by previous mutations`, func(t *testcase.T) {
+				assert.Must(t).NotContain(t.I(`input`).([]int), 42)
+				assert.Must(t).NotContain(row.(tableTestRow).Input, 42)
+			})
+		}, testcase.Group(`Table`))
+
+		s.Table(`stringer`, []tableTestStringerRow{{value: 1}}, func(s *testcase.Spec, row interface{}) {
+			s.Test(`test`, func(t *testcase.T) { addName(t) })
+		}, testcase.Group(`Stringer`))
+	})
+
+	t.Cleanup(func() {
+		sort.Strings(names)
+		assert.Must(t).Equal([]string{
+			`TestSpec_Table/#Sum/Stringer/value_is_1_test`,
+			`TestSpec_Table/#Sum/Table/#2_sum`,
+			`TestSpec_Table/#Sum/Table/empty_sum`,
+			`TestSpec_Table/#Sum/Table/positive_sum`,
+		}, names)
+	})
+}
+
+func TestSpec_Table_rowsMustBeSliceOrArray(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	willFatalWithMessage := willFatalWithMessageFn(stub)
+	assert.Must(t).Contain(willFatalWithMessage(t, func() {
+		s.Table(`table`, 42, func(s *testcase.Spec, row interface{}) {})
+	}), `Table rows must be a slice or an array, but got int`)
+}
+
+func TestSpec_Property(t *testing.T) {
+	stub := &internal.StubTB{}
+	rtb := &internal.RecorderTB{TB: stub}
+	s := testcase.NewSpec(rtb)
+
+	var (
+		runs    int
+		befores int
+	)
+	s.Before(func(t *testcase.T) { befores++ })
+	s.Property(`addition is commutative`, func(t *testcase.T, gen *testcase.Gen) {
+		runs++
+		a, b := gen.IntBetween(-1000, 1000), gen.IntBetween(-1000, 1000)
+		t.Must.Equal(a+b, b+a)
+	}, testcase.PropertyRuns(42))
+	rtb.CleanupNow()
+
+	assert.Must(t).True(!rtb.IsFailed)
+	assert.Must(t).Equal(42, runs)
+	assert.Must(t).Equal(42, befores)
+}
+
+func TestSpec_Property_shrinksTheFailingInput(t *testing.T) {
+	const seed = `42`
+	testcase.SetEnv(t, testcase.EnvKeySeed, seed)
+	check := func(blk func(t *testcase.T, gen *testcase.Gen)) string {
+		stub := &internal.StubTB{StubName: t.Name()}
+		s := testcase.NewSpec(stub)
+		s.Property(``, blk)
+		stub.Finish()
+		assert.Must(t).True(stub.IsFailed)
+		logs := strings.Join(stub.Logs, "\n")
+		assert.Must(t).Contain(logs, `minimal failing input`)
+		assert.Must(t).Contain(logs, `replay with TESTCASE_SEED=`+seed)
+		return logs
+	}
+
+	t.Run(`integers shrink toward zero`, func(t *testing.T) {
+		logs := check(func(t *testcase.T, gen *testcase.Gen) {
+			if n := gen.Int(); 100 < n {
+				t.Errorf(`%d is too big`, n)
+			}
+		})
+		assert.Must(t).Contain(logs, "Int: 101\n")
+		assert.Must(t).Contain(logs, `101 is too big`)
+	})
+	t.Run(`strings shrink toward shorter ones`, func(t *testing.T) {
+		logs := check(func(t *testcase.T, gen *testcase.Gen) {
+			if str := gen.String(); 3 < len(str) {
+				t.Errorf(`%q is too long`, str)
+			}
+		})
+		assert.Must(t).Contain(logs, "String: \"0000\"\n")
+	})
+	t.Run(`structs shrink toward their zero value`, func(t *testing.T) {
+		type Pair struct {
+			A int
+			B []int
+		}
+		logs := check(func(t *testcase.T, gen *testcase.Gen) {
+			p := gen.Make(Pair{}).(Pair)
+			if 1 < len(p.B) {
+				t.Fatal(`too many elements`)
+			}
+		})
+		assert.Must(t).Contain(logs, "Pair{A:0, B:[]int{0, 0}}\n")
+		assert.Must(t).Contain(logs, `too many elements`)
+	})
+}
+
+func TestSpec_Property_deterministicWithTheSameSeed(t *testing.T) {
+	testcase.SetEnv(t, testcase.EnvKeySeed, `42`)
+	generate := func() []int {
+		var out []int
+		rtb := &internal.RecorderTB{TB: &internal.StubTB{StubName: t.Name()}}
+		s := testcase.NewSpec(rtb)
+		s.Property(``, func(t *testcase.T, gen *testcase.Gen) {
+			out = append(out, gen.Int())
+		}, testcase.PropertyRuns(10))
+		rtb.CleanupNow()
+		return out
+	}
+	assert.Must(t).Equal(generate(), generate())
+}
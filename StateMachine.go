@@ -0,0 +1,172 @@
+package testcase
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/adamluzsi/testcase/internal"
+)
+
+// StateMachine is a model based testing helper,
+// that verifies a system by executing random sequences of commands against it.
+// Each command runs against both the system under test and a simple reference model,
+// and asserts that their outcome is the same.
+// After each step, the Invariant is checked.
+//
+// Every command sequence starts from a fresh testing state,
+// where the Let variables and the hooks are evaluated again,
+// thus the system under test and the model can be set up with Let variables.
+//
+// When a sequence fails, it is shrunk to the shortest failing sequence,
+// and the failing sequence is logged along with the TESTCASE_SEED that replays it.
+type StateMachine struct {
+	// Commands are the operations that the sequences are built from.
+	Commands []StateMachineCommand
+	// Invariant is an optional assertion that is checked after each step of the sequence.
+	Invariant func(t *T)
+	// Runs is the number of the command sequences to check.
+	// By default it is 100.
+	Runs int
+	// MaxSteps is the maximum length of a command sequence.
+	// By default it is 20.
+	MaxSteps int
+}
+
+// StateMachineCommand is an operation of the StateMachine.
+type StateMachineCommand struct {
+	// Name is used in the failing sequence's report.
+	Name string
+	// Precondition is optional, and when it is provided,
+	// the command is only selected in the states where the precondition holds.
+	Precondition func(t *T) bool
+	// Run executes the command against the system under test and the model, and asserts the outcome.
+	// The command arguments should be generated with the received Gen,
+	// so they are shrunk and reported as well.
+	Run func(t *T, gen *Gen)
+}
+
+const (
+	defaultStateMachineRuns     = 100
+	defaultStateMachineMaxSteps = 20
+)
+
+// Check executes the command sequences within the received test.
+func (sm StateMachine) Check(t *T) {
+	t.TB.Helper()
+	if len(sm.Commands) == 0 {
+		t.Fatal(`StateMachine has no Commands`)
+	}
+	seeds := rand.New(rand.NewSource(int64(t.Random.Int())))
+	for run := 1; run <= sm.getRuns(); run++ {
+		gen := newGen(rand.NewSource(seeds.Int63()), nil)
+		if _, failed := sm.try(t, gen); !failed {
+			continue
+		}
+
+		shrinker := propertyShrinker{
+			choices: gen.source.choices,
+			fails: func(choices []int64) ([]int64, bool) {
+				gen := newGen(nil, choices)
+				_, failed := sm.try(t, gen)
+				return gen.source.choices, failed
+			},
+		}
+		minimal := newGen(nil, shrinker.shrink())
+		rtb := &internal.RecorderTB{TB: t.TB}
+		steps := sm.exec(rtb, t, minimal)
+		t.Error(sm.report(t, run, steps, minimal.getValues()))
+		rtb.Forward()
+		return
+	}
+}
+
+func (sm StateMachine) getRuns() int {
+	if sm.Runs <= 0 {
+		return defaultStateMachineRuns
+	}
+	return sm.Runs
+}
+
+func (sm StateMachine) getMaxSteps() int {
+	if sm.MaxSteps <= 0 {
+		return defaultStateMachineMaxSteps
+	}
+	return sm.MaxSteps
+}
+
+type stateMachineStep struct {
+	name string
+	// from is the index of the first generated value of the step
+	from int
+}
+
+func (sm StateMachine) try(t *T, gen *Gen) ([]stateMachineStep, bool) {
+	t.TB.Helper()
+	rtb := &internal.RecorderTB{TB: t.TB}
+	defer rtb.CleanupNow()
+	steps := sm.exec(rtb, t, gen)
+	return steps, rtb.IsFailed
+}
+
+// exec runs a command sequence with a fresh testing state.
+func (sm StateMachine) exec(rtb *internal.RecorderTB, t *T, gen *Gen) (steps []stateMachineStep) {
+	t.TB.Helper()
+	internal.RecoverExceptGoexit(func() {
+		st := newT(rtb, t.spec)
+		defer t.spec.recoverFromPanic(rtb, st)
+		defer st.setUp()()
+		maxSteps := sm.getMaxSteps()
+		for len(steps) < maxSteps {
+			// a zero choice ends the sequence, thus shrinking makes the sequence shorter.
+			if gen.random.IntN(maxSteps) == 0 {
+				return
+			}
+			var enabled []int
+			for i, cmd := range sm.Commands {
+				if cmd.Precondition == nil || cmd.Precondition(st) {
+					enabled = append(enabled, i)
+				}
+			}
+			if len(enabled) == 0 {
+				return
+			}
+			index := enabled[gen.random.IntN(len(enabled))]
+			steps = append(steps, stateMachineStep{
+				name: sm.commandName(index),
+				from: len(gen.getValues()),
+			})
+			sm.Commands[index].Run(st, gen)
+			if sm.Invariant != nil {
+				sm.Invariant(st)
+			}
+		}
+	})
+	return steps
+}
+
+func (sm StateMachine) commandName(index int) string {
+	if name := sm.Commands[index].Name; name != `` {
+		return name
+	}
+	return fmt.Sprintf(`command #%d`, index)
+}
+
+func (sm StateMachine) report(t *T, run int, steps []stateMachineStep, values []string) string {
+	var msg strings.Builder
+	_, _ = fmt.Fprintf(&msg, "state machine failed at run #%d\n", run)
+	_, _ = fmt.Fprintf(&msg, "\nshortest failing sequence (%d steps):\n", len(steps))
+	for i, step := range steps {
+		to := len(values)
+		if i+1 < len(steps) {
+			to = steps[i+1].from
+		}
+		_, _ = fmt.Fprintf(&msg, "\t%d. %s", i+1, step.name)
+		if step.from < to {
+			_, _ = fmt.Fprintf(&msg, "(%s)", strings.Join(values[step.from:to], `, `))
+		}
+		msg.WriteString("\n")
+	}
+	_, _ = fmt.Fprintf(&msg, "\nreplay with %s=%d", EnvKeySeed, t.spec.seed)
+	return msg.String()
+}
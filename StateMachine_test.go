@@ -0,0 +1,127 @@
+package testcase_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal"
+)
+
+// buggyQueue returns the last element instead of the first one, once it holds at least 3 elements.
+type buggyQueue struct{ items []int }
+
+func (q *buggyQueue) Push(v int) { q.items = append(q.items, v) }
+
+func (q *buggyQueue) Pop() int {
+	var v int
+	if 3 <= len(q.items) {
+		v, q.items = q.items[len(q.items)-1], q.items[:len(q.items)-1]
+		return v
+	}
+	v, q.items = q.items[0], q.items[1:]
+	return v
+}
+
+func (q *buggyQueue) Len() int { return len(q.items) }
+
+func queueStateMachine(s *testcase.Spec) testcase.StateMachine {
+	var (
+		queue = s.Let(`queue`, func(t *testcase.T) interface{} { return &buggyQueue{} })
+		model = s.Let(`model`, func(t *testcase.T) interface{} { return &[]int{} })
+	)
+	return testcase.StateMachine{
+		Commands: []testcase.StateMachineCommand{
+			{
+				Name: `Push`,
+				Run: func(t *testcase.T, gen *testcase.Gen) {
+					v := gen.IntBetween(0, 9)
+					queue.Get(t).(*buggyQueue).Push(v)
+					m := model.Get(t).(*[]int)
+					*m = append(*m, v)
+				},
+			},
+			{
+				Name: `Pop`,
+				Precondition: func(t *testcase.T) bool {
+					return 0 < len(*model.Get(t).(*[]int))
+				},
+				Run: func(t *testcase.T, gen *testcase.Gen) {
+					m := model.Get(t).(*[]int)
+					var expected int
+					expected, *m = (*m)[0], (*m)[1:]
+					t.Must.Equal(expected, queue.Get(t).(*buggyQueue).Pop())
+				},
+			},
+		},
+		Invariant: func(t *testcase.T) {
+			t.Must.Equal(len(*model.Get(t).(*[]int)), queue.Get(t).(*buggyQueue).Len())
+		},
+	}
+}
+
+func TestStateMachine_Check(t *testing.T) {
+	t.Run(`on a failing sequence, the shortest failing sequence is reported`, func(t *testing.T) {
+		const seed = `42`
+		testcase.SetEnv(t, testcase.EnvKeySeed, seed)
+		stub := &internal.StubTB{StubName: t.Name()}
+		s := testcase.NewSpec(stub)
+		sm := queueStateMachine(s)
+		s.Test(``, sm.Check)
+		stub.Finish()
+
+		assert.Must(t).True(stub.IsFailed)
+		logs := strings.Join(stub.Logs, "\n")
+		assert.Must(t).Contain(logs, "shortest failing sequence (4 steps):\n")
+		assert.Must(t).Contain(logs, "\t4. Pop\n")
+		assert.Must(t).Equal(3, strings.Count(logs, `. Push(IntBetween: `))
+		assert.Must(t).Contain(logs, `replay with TESTCASE_SEED=`+seed)
+	})
+
+	t.Run(`each sequence starts from a fresh state`, func(t *testing.T) {
+		rtb := &internal.RecorderTB{TB: &internal.StubTB{StubName: t.Name()}}
+		s := testcase.NewSpec(rtb)
+
+		var (
+			befores int
+			lens    []int
+		)
+		s.Before(func(t *testcase.T) { befores++ })
+		counter := s.Let(`counter`, func(t *testcase.T) interface{} { return new(int) })
+		s.Test(``, testcase.StateMachine{
+			Runs: 10,
+			Commands: []testcase.StateMachineCommand{{
+				Name: `Inc`,
+				Run:  func(t *testcase.T, gen *testcase.Gen) { *counter.Get(t).(*int)++ },
+			}},
+			Invariant: func(t *testcase.T) {
+				lens = append(lens, *counter.Get(t).(*int))
+			},
+		}.Check)
+		rtb.CleanupNow()
+
+		assert.Must(t).True(!rtb.IsFailed)
+		assert.Must(t).Equal(1+10, befores)
+		for i := 1; i < len(lens); i++ {
+			assert.Must(t).True(lens[i] == lens[i-1]+1 || lens[i] == 1)
+		}
+	})
+
+	t.Run(`commands are selected only when their precondition holds`, func(t *testing.T) {
+		rtb := &internal.RecorderTB{TB: &internal.StubTB{StubName: t.Name()}}
+		s := testcase.NewSpec(rtb)
+		s.Test(``, testcase.StateMachine{
+			Commands: []testcase.StateMachineCommand{{
+				Name:         `Never`,
+				Precondition: func(t *testcase.T) bool { return false },
+				Run:          func(t *testcase.T, gen *testcase.Gen) { t.Fatal(`unexpected`) },
+			}, {
+				Name: `Always`,
+				Run:  func(t *testcase.T, gen *testcase.Gen) {},
+			}},
+		}.Check)
+		rtb.CleanupNow()
+		assert.Must(t).True(!rtb.IsFailed)
+	})
+}
@@ -1,11 +1,13 @@
 package testcase
 
 import (
+	"fmt"
 	"math/rand"
 	"testing"
 	"time"
 
 	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/clock"
 	"github.com/adamluzsi/testcase/random"
 
 	"github.com/adamluzsi/testcase/internal"
@@ -22,16 +24,21 @@ func NewT(tb testing.TB, spec *Spec) *T {
 }
 
 func newT(tb testing.TB, spec *Spec) *T {
-	return &T{
+	t := &T{
 		TB:     tb,
-		Random: random.New(rand.NewSource(spec.seed)),
-		It:     assert.MakeIt(tb),
+		Random: random.New(rand.NewSource(spec.testSeed())),
+		Clock:  clock.NewFake(time.Now()),
 
 		spec:     spec,
 		vars:     newVariables(),
 		tags:     spec.getTagSet(),
 		teardown: &internal.Teardown{CallerOffset: 1},
+		phase:    &testPhase{},
+		context:  &testContext{},
 	}
+	// the assertions fail through T, so their failures are recorded for the test result as well
+	t.It = assert.MakeIt(t)
+	return t
 }
 
 // T embeds both testcase vars, and testing#T functionality.
@@ -43,12 +50,22 @@ func newT(tb testing.TB, spec *Spec) *T {
 type T struct {
 	// TB is the interface common to T and B.
 	testing.TB
-	// Random is a random generator that uses the Spec seed.
+	// Random is a random generator that uses a seed derived from the Spec seed and the test's description path,
+	// thus the tests receive independent random values.
 	//
 	// When a test fails with random input from Random generator,
 	// the failed test scenario can be recreated simply by providing the same TESTCASE_SEED
-	// as you can read from the console output of the failed test.
+	// as you can read from the console output of the failed test,
+	// along with a go test command that runs only the failed test.
 	Random *random.Random
+	// Clock is a fake clock for the test, which can be injected into the time-dependent code under test.
+	// Each test receives its own Clock, and its time flows as the real time does,
+	// until the test takes control of it with Clock.Freeze, Clock.Advance or Clock.Travel.
+	// To let a Waiter or a Backoff wait in the fake time, pass the Clock to them,
+	// but keep in mind, that Waiter.Wait advances the Clock while polling,
+	// thus the test observes the time moving forward by the waited durations.
+	// The Clock is reset with the rest of the test state before each run of the test block.
+	Clock *clock.Fake
 	// It provides asserters to make assertion easier.
 	// Must Interface will use FailNow on a failed assertion.
 	// This will make test exit early on.
@@ -60,6 +77,16 @@ type T struct {
 	vars     *variables
 	tags     map[string]struct{}
 	teardown *internal.Teardown
+	phase    *testPhase
+	gen      *Gen
+	// benchmark is only present when the test is executed as a benchmark.
+	benchmark *benchmark
+	// hooks are the hooks that ran as part of the test's set-up.
+	hooks []ranHook
+	// context is the context of the current test execution, see T.Context.
+	context *testContext
+	// results are the test results which record the failure messages and the skip reason of the test.
+	results []*testResult
 
 	cache struct {
 		contexts []*Spec
@@ -88,6 +115,14 @@ func (t *T) Set(varName string, value interface{}) {
 	t.vars.Set(varName, value)
 }
 
+// LogVarGraph logs the variables initialized so far in the test,
+// along with the variables their Let block depends on, and the location where they were declared.
+// This helps to understand the dependencies between the variables of heavy spec helpers.
+func (t *T) LogVarGraph() {
+	t.TB.Helper()
+	t.Log(t.vars.graph())
+}
+
 // Let is an alias for T.Set for backward compatibility.
 //
 // DEPRECATED: use T.Set instead
@@ -135,7 +170,11 @@ func (t *T) Defer(fn interface{}, args ...interface{}) {
 // to always execute the teardown
 func (t *T) setUp() func() {
 	t.TB.Helper()
+	checkGoroutineLeaks := t.detectGoroutineLeaks()
 	t.vars.reset()
+	t.hooks = nil
+	t.Clock = clock.NewFake(time.Now())
+	cancel := t.startContext()
 
 	contexts := t.contexts()
 	for _, c := range contexts {
@@ -143,12 +182,25 @@ func (t *T) setUp() func() {
 	}
 
 	for _, c := range contexts {
-		for _, hook := range c.hooks.Around {
-			t.teardown.Defer(hook(t))
+		for _, h := range c.hooks.Around {
+			h, scope := h, c.hookScopeName()
+			t.hooks = append(t.hooks, ranHook{hook: h, scope: scope})
+			t.phase.setHook(h, scope)
+			teardown := h.block(t)
+			t.teardown.Defer(func() {
+				t.phase.setHook(h, scope)
+				teardown()
+				t.phase.set(`teardown`)
+			})
 		}
 	}
 
-	return t.teardown.Finish
+	return func() {
+		t.phase.set(`teardown`)
+		defer checkGoroutineLeaks()
+		defer cancel()
+		t.teardown.Finish()
+	}
 }
 
 func (t *T) HasTag(tag string) bool {
@@ -183,13 +235,21 @@ var DefaultEventuallyRetry = Retry{Strategy: Waiter{WaitTimeout: 3 * time.Second
 // until the expectations in the function body yield no testing failure.
 // Calling multiple times the assertion function block content should be a safe and repeatable operation.
 // For more, read the documentation of Retry and Retry.Assert.
-// In case Spec doesn't have a configuration for how to retry Eventually, the DefaultEventuallyRetry will be used.
+// The retry can be configured with a retry count, a timeout or a RetryStrategy as the optional argument.
+// In case neither the argument, nor the Spec has a configuration for how to retry Eventually,
+// the DefaultEventuallyRetry will be used.
 func (t *T) Eventually(blk func(it assert.It), retryOpts ...interface{}) {
 	t.TB.Helper()
 	retry, ok := t.spec.lookupRetryEventually()
 	if !ok {
 		retry = DefaultEventuallyRetry
 	}
+	if 0 < len(retryOpts) {
+		retry, ok = makeRetry(retryOpts[0])
+		if !ok {
+			panic(fmt.Errorf(`%T is not supported by Eventually`, retryOpts[0]))
+		}
+	}
 	retry.Assert(t, func(tb testing.TB) {
 		blk(assert.MakeIt(tb))
 	})
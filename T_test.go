@@ -4,10 +4,12 @@ import (
 	"context"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/clock"
 	"github.com/adamluzsi/testcase/fixtures"
 
 	"github.com/adamluzsi/testcase/random"
@@ -328,12 +330,27 @@ func TestT_Random(t *testing.T) {
 		testcase.SetEnv(t, testcase.EnvKeySeed, `42`)
 		s := testcase.NewSpec(t)
 		s.Test(``, func(t *testcase.T) {
-			t.Must.Equal(random.New(rand.NewSource(42)), t.Random)
-
 			randomGenerationWorks(t)
 		})
 	})
 
+	t.Run(`when environment value is set, each test receives its own reproducible random values`, func(t *testing.T) {
+		testcase.SetEnv(t, testcase.EnvKeySeed, `42`)
+		collect := func() map[string]int {
+			values := make(map[string]int)
+			stub := &internal.StubTB{StubName: t.Name()}
+			s := testcase.NewSpec(stub)
+			s.Test(`A`, func(t *testcase.T) { values[`A`] = t.Random.Int() })
+			s.Test(`B`, func(t *testcase.T) { values[`B`] = t.Random.Int() })
+			stub.Finish()
+			return values
+		}
+		first := collect()
+		assert.Must(t).Equal(first, collect())
+		assert.Must(t).NotEqual(first[`A`], first[`B`])
+		assert.Must(t).NotEqual(random.New(rand.NewSource(42)).Int(), first[`A`])
+	})
+
 	s := testcase.NewSpec(t)
 	s.Test(``, func(t *testcase.T) {
 		randomGenerationWorks(t)
@@ -378,6 +395,21 @@ func TestT_Eventually(t *testing.T) {
 		assert.Must(t).True(!stub.IsFailed, `expected to pass`)
 		assert.Must(t).True(strategyUsed, `retry strategy of the eventually call was used`)
 	})
+
+	t.Run(`with retry argument, it overrides the spec configuration`, func(t *testing.T) {
+		stub := &internal.StubTB{}
+		s := testcase.NewSpec(stub, testcase.RetryStrategyForEventually(testcase.RetryCount(0)))
+		var attempts []int
+		s.Test(``, func(t *testcase.T) {
+			t.Eventually(func(it assert.It) {
+				attempts = append(attempts, testcase.RetryAttempt(it.Must.TB))
+				it.Must.True(3 <= len(attempts))
+			}, testcase.Either(testcase.RetryCount(5), testcase.Backoff{Timeout: time.Minute}))
+		})
+		stub.Finish()
+		assert.Must(t).True(!stub.IsFailed, `expected to pass`)
+		assert.Must(t).Equal([]int{1, 2, 3}, attempts)
+	})
 }
 
 func TestNewT(t *testing.T) {
@@ -407,3 +439,115 @@ func TestNewT(t *testing.T) {
 		assert.Must(t).Equal(vGet(subject), vGet(subject), `has test variable cache`)
 	})
 }
+
+func TestT_Clock(t *testing.T) {
+	t.Run(`by default, the time of the clock flows as the real time`, func(t *testing.T) {
+		stub := &internal.StubTB{}
+		s := testcase.NewSpec(stub)
+		s.Test(``, func(t *testcase.T) {
+			before := time.Now()
+			t.Must.True(!t.Clock.Now().Before(before.Add(-time.Second)))
+			t.Clock.Sleep(time.Millisecond)
+			t.Must.True(!t.Clock.Now().Before(before.Add(time.Millisecond)))
+		})
+		stub.Finish()
+		assert.Must(t).True(!stub.IsFailed)
+	})
+
+	t.Run(`each test has its own clock`, func(t *testing.T) {
+		stub := &internal.StubTB{}
+		s := testcase.NewSpec(stub)
+		s.HasSideEffect()
+		var clocks []*clock.Fake
+		for i := 0; i < 2; i++ {
+			s.Test(``, func(t *testcase.T) {
+				clocks = append(clocks, t.Clock)
+				t.Clock.Travel(time.Now().Add(24 * time.Hour))
+			})
+		}
+		stub.Finish()
+		assert.Must(t).Equal(2, len(clocks))
+		assert.Must(t).True(clocks[0] != clocks[1])
+	})
+
+	t.Run(`when the clock is used by Eventually, the timers fire without waiting real time`, func(t *testing.T) {
+		stub := &internal.StubTB{}
+		s := testcase.NewSpec(stub)
+		s.Test(``, func(t *testcase.T) {
+			t.Clock.Freeze()
+			var done int32
+			timer := t.Clock.NewTimer(time.Hour)
+			go func() {
+				<-timer.C()
+				atomic.StoreInt32(&done, 1)
+			}()
+			t.Eventually(func(it assert.It) {
+				it.Must.Equal(int32(1), atomic.LoadInt32(&done))
+			}, testcase.Waiter{WaitDuration: time.Minute, WaitTimeout: 2 * time.Hour, Clock: t.Clock})
+		})
+		start := time.Now()
+		stub.Finish()
+		assert.Must(t).True(!stub.IsFailed)
+		assert.Must(t).True(time.Since(start) < 30*time.Second)
+	})
+}
+
+func TestT_Context(t *testing.T) {
+	t.Run(`the context is canceled when the test is finished`, func(t *testing.T) {
+		stub := &internal.StubTB{}
+		s := testcase.NewSpec(stub)
+		var (
+			letCtx, afterCtx context.Context
+			errInTest        error
+			errInAfter       error
+		)
+		v := testcase.Let(s, `ctx`, func(t *testcase.T) context.Context {
+			letCtx = t.Context()
+			return letCtx
+		})
+		s.After(func(t *testcase.T) {
+			afterCtx = t.Context()
+			errInAfter = afterCtx.Err()
+		})
+		s.Test(``, func(t *testcase.T) {
+			errInTest = v.Get(t).Err()
+			t.Eventually(func(it assert.It) {
+				it.Must.Equal(letCtx, t.Context())
+			})
+		})
+		stub.Finish()
+
+		assert.Must(t).True(!stub.IsFailed)
+		assert.Must(t).Nil(errInTest)
+		assert.Must(t).Nil(errInAfter, `After hooks can use the context`)
+		assert.Must(t).Equal(letCtx, afterCtx)
+		assert.Must(t).Equal(context.Canceled, letCtx.Err())
+	})
+
+	t.Run(`each test receives its own context`, func(t *testing.T) {
+		stub := &internal.StubTB{}
+		s := testcase.NewSpec(stub)
+		var ctxs []context.Context
+		s.Before(func(t *testcase.T) { ctxs = append(ctxs, t.Context()) })
+		s.Test(`1`, func(t *testcase.T) {})
+		s.Test(`2`, func(t *testcase.T) {})
+		stub.Finish()
+
+		assert.Must(t).Equal(2, len(ctxs))
+		assert.Must(t).True(ctxs[0] != ctxs[1])
+	})
+
+	t.Run(`with Timeout, the context has the deadline of the test`, func(t *testing.T) {
+		stub := &internal.StubTB{}
+		s := testcase.NewSpec(stub, testcase.Timeout(time.Hour))
+		var (
+			deadline time.Time
+			ok       bool
+		)
+		s.Test(``, func(t *testcase.T) { deadline, ok = t.Context().Deadline() })
+		stub.Finish()
+
+		assert.Must(t).True(ok)
+		assert.Must(t).True(time.Until(deadline) <= time.Hour)
+	})
+}
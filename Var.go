@@ -1,6 +1,10 @@
 package testcase
 
-import "reflect"
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
 
 // TODO: update Ts to [T] when Go2 released
 
@@ -77,6 +81,48 @@ func (v Var) Let(s *Spec, blk letBlock) Var {
 	return s.Let(v.Name, blk)
 }
 
+const varLetSuperWithoutParent = `%s Var has no definition in the outer scopes, and it has no Var.Init either, thus Var.LetSuper has nothing to build on.`
+
+// LetSuper allow you to override the variable in the given spec, while building on the definition of the outer scope.
+// The received super function evaluates the outer scope's definition of the variable,
+// or the Var.Init when the variable is not defined in the outer scopes.
+// Within a test, super evaluates the outer definition only once.
+//
+// example usage:
+// 	user.LetSuper(s, func(t *testcase.T, super func() interface{}) interface{} {
+// 		u := super().(User)
+// 		u.Role = `admin`
+// 		return u
+// 	})
+func (v Var) LetSuper(s *Spec, blk func(t *T, super func() interface{}) interface{}) Var {
+	s.testingTB.Helper()
+	outer, ok := s.lookupLet(v.Name)
+	if !ok && v.Init != nil {
+		outer, ok = v.Init, true
+	}
+	if !ok {
+		s.testingTB.Fatalf(varLetSuperWithoutParent, v.Name)
+	}
+	return v.Let(s, func(t *T) interface{} {
+		var (
+			once  sync.Once
+			value interface{}
+		)
+		return blk(t, func() interface{} {
+			t.Helper()
+			once.Do(func() { value = outer(t) })
+			return value
+		})
+	})
+}
+
+// LetShared allow you to set a shared variable value to a given spec.
+// For more, read the documentation of Spec.LetShared.
+func (v Var) LetShared(s *Spec, blk func(tb testing.TB) interface{}) Var {
+	v.onLet(s)
+	return s.LetShared(v.Name, blk)
+}
+
 func (v Var) onLet(s *Spec) {
 	if v.OnLet != nil {
 		v.OnLet(s)
@@ -0,0 +1,141 @@
+package testcase
+
+import (
+	"reflect"
+	"testing"
+)
+
+// VarOf is the typed version of Var, where the type parameter V is the type of the variable's value,
+// thus VarOf.Get returns a typed value without any type casting.
+//
+// Variables are identified by their Name, regardless of their type,
+// thus a Var and a VarOf with the same Name access the same variable.
+// This allows migrating a testing suite from Var to VarOf gradually.
+type VarOf[V any] struct {
+	// Name is the testCase spec variable group from where the cached value can be accessed later on.
+	// Name is Mandatory when you create a variable, else the empty string will be used as the variable group.
+	Name string
+	// Init is an optional constructor definition that will be used when VarOf is bonded to a *Spec without constructor function passed to the Let function.
+	// For more, read the documentation of Var.Init.
+	Init func(t *T) V
+	// Before is a hook that will be executed once during the lifetime of tests that uses the VarOf.
+	// If the VarOf is not bound to the Spec at Spec.Context level, the Before Hook will be executed at VarOf.Get.
+	Before block
+	// OnLet is an optional hook that is executed when the variable being bind to Spec context.
+	// For more, read the documentation of Var.OnLet.
+	OnLet contextBlock
+}
+
+const varTypeMismatch = `%s Var value has the type of %T, which is not assignable to the type of the Var: %s`
+
+// Get returns the current cached value of the given Variable
+// Get is a thread safe operation.
+func (v VarOf[V]) Get(t *T) V {
+	t.Helper()
+	return v.cast(t, v.untyped().Get(t))
+}
+
+// Set sets a value to a given variable during testCase runtime
+// Set is a thread safe operation.
+func (v VarOf[V]) Set(t *T, value V) {
+	v.untyped().Set(t, value)
+}
+
+// Let allow you to set the variable value to a given spec
+func (v VarOf[V]) Let(s *Spec, blk func(t *T) V) VarOf[V] {
+	v.untyped().Let(s, v.letBlock(blk))
+	if blk == nil {
+		blk = v.Init
+	}
+	return VarOf[V]{Name: v.Name, Init: blk}
+}
+
+// LetValue set the value of the variable to a given block
+func (v VarOf[V]) LetValue(s *Spec, value V) VarOf[V] {
+	v.untyped().LetValue(s, value)
+	return VarOf[V]{Name: v.Name, Init: func(t *T) V {
+		v := value // pass by value copy
+		return v
+	}}
+}
+
+// LetSuper allow you to override the variable in the given spec, while building on the definition of the outer scope.
+// For more, read the documentation of Var.LetSuper.
+func (v VarOf[V]) LetSuper(s *Spec, blk func(t *T, super func() V) V) VarOf[V] {
+	s.testingTB.Helper()
+	v.untyped().LetSuper(s, func(t *T, super func() interface{}) interface{} {
+		return blk(t, func() V {
+			t.Helper()
+			return v.cast(t, super())
+		})
+	})
+	return VarOf[V]{Name: v.Name}
+}
+
+// LetShared allow you to set a shared variable value to a given spec.
+// For more, read the documentation of Spec.LetShared.
+func (v VarOf[V]) LetShared(s *Spec, blk func(tb testing.TB) V) VarOf[V] {
+	v.untyped().LetShared(s, func(tb testing.TB) interface{} { return blk(tb) })
+	return VarOf[V]{Name: v.Name}
+}
+
+// Bind is a syntax sugar shorthand for VarOf.Let(*Spec, nil),
+// where skipping providing a block meant to be explicitly expressed.
+func (v VarOf[V]) Bind(s *Spec) VarOf[V] {
+	return v.Let(s, nil)
+}
+
+// EagerLoading allows the variable to be loaded before the action and assertion block is reached.
+// For more, read the documentation of Var.EagerLoading.
+func (v VarOf[V]) EagerLoading(s *Spec) VarOf[V] {
+	v.untyped().EagerLoading(s)
+	return v
+}
+
+func (v VarOf[V]) untyped() Var {
+	return Var{
+		Name:   v.Name,
+		Init:   v.letBlock(v.Init),
+		Before: v.Before,
+		OnLet:  v.OnLet,
+	}
+}
+
+func (v VarOf[V]) letBlock(blk func(t *T) V) letBlock {
+	if blk == nil {
+		return nil
+	}
+	return func(t *T) interface{} { return blk(t) }
+}
+
+func (v VarOf[V]) cast(t *T, value interface{}) V {
+	t.Helper()
+	if value == nil {
+		var zero V
+		return zero
+	}
+	r, ok := value.(V)
+	if !ok {
+		t.Fatalf(varTypeMismatch, v.Name, value, reflect.TypeOf((*V)(nil)).Elem())
+	}
+	return r
+}
+
+// Let is the typed version of Spec.Let, which defines a memoized variable in the given spec,
+// and returns a VarOf with the type of the value returned by the block.
+//
+// example usage:
+// 	myStruct := testcase.Let(s, `my struct`, func(t *testcase.T) *MyStruct {
+// 		return &MyStruct{}
+// 	})
+// 	myStruct.Get(t).Method()
+func Let[V any](s *Spec, varName string, blk func(t *T) V) VarOf[V] {
+	s.testingTB.Helper()
+	return VarOf[V]{Name: varName}.Let(s, blk)
+}
+
+// LetValue is the typed version of Spec.LetValue.
+func LetValue[V any](s *Spec, varName string, value V) VarOf[V] {
+	s.testingTB.Helper()
+	return VarOf[V]{Name: varName}.LetValue(s, value)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -777,3 +778,183 @@ func TestVar_Before(t *testing.T) {
 		})
 	})
 }
+
+func TestLet(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	v := testcase.Let(s, `value`, func(t *testcase.T) int { return 42 })
+	untyped := testcase.Var{Name: v.Name}
+	var (
+		initial, updated int
+		fromUntyped      interface{}
+	)
+	s.Test(``, func(t *testcase.T) {
+		initial = v.Get(t)
+		v.Set(t, 24)
+		updated = v.Get(t)
+		fromUntyped = untyped.Get(t)
+	})
+	stub.Finish()
+	assert.Must(t).True(!stub.IsFailed)
+	assert.Must(t).Equal(42, initial)
+	assert.Must(t).Equal(24, updated)
+	assert.Must(t).Equal(24, fromUntyped)
+}
+
+func TestLetValue(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	v := testcase.LetValue(s, `value`, `foo`)
+	var got string
+	s.Test(``, func(t *testcase.T) { got = v.Get(t) })
+	stub.Finish()
+	assert.Must(t).True(!stub.IsFailed)
+	assert.Must(t).Equal(`foo`, got)
+}
+
+func TestVarOf_Get_typeMismatch(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	s.LetValue(`value`, 42)
+	v := testcase.VarOf[string]{Name: `value`}
+	s.Test(``, func(t *testcase.T) { _ = v.Get(t) })
+	stub.Finish()
+	assert.Must(t).True(stub.IsFailed)
+	assert.Must(t).Contain(strings.Join(stub.Logs, "\n"),
+		`value Var value has the type of int, which is not assignable to the type of the Var: string`)
+}
+
+func TestVar_Get_dependencyCycle(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	var a, b testcase.VarOf[int]
+	a = testcase.Let(s, `a`, func(t *testcase.T) int { return b.Get(t) + 1 })
+	b = testcase.Let(s, `b`, func(t *testcase.T) int { return a.Get(t) + 1 })
+	s.Test(``, func(t *testcase.T) { _ = a.Get(t) })
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		stub.Finish()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal(`the dependency cycle made the test hang`)
+	}
+
+	assert.Must(t).True(stub.IsFailed)
+	logs := strings.Join(stub.Logs, "\n")
+	assert.Must(t).Contain(logs, `Let variable dependency cycle detected: a -> b -> a`)
+	assert.Must(t).Contain(logs, `a (Var_test.go:`)
+	assert.Must(t).Contain(logs, `b (Var_test.go:`)
+}
+
+func TestVar_Get_concurrentInitializationIsNotACycle(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	var inits int32
+	v := testcase.Let(s, `v`, func(t *testcase.T) int {
+		atomic.AddInt32(&inits, 1)
+		time.Sleep(10 * time.Millisecond)
+		return 42
+	})
+	s.Test(``, func(t *testcase.T) {
+		testcase.Race(func() { _ = v.Get(t) }, func() { _ = v.Get(t) })
+	})
+	stub.Finish()
+	assert.Must(t).True(!stub.IsFailed, strings.Join(stub.Logs, "\n"))
+	assert.Must(t).Equal(int32(1), atomic.LoadInt32(&inits))
+}
+
+func TestVar_Get_letBlockReceivesTheTestsT(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	var letT *testcase.T
+	v := testcase.Let(s, `v`, func(t *testcase.T) int {
+		letT = t
+		return 42
+	})
+	s.Test(``, func(t *testcase.T) {
+		_ = v.Get(t)
+		assert.Must(t).True(letT == t, `the Let block should receive the test's own T`)
+	})
+	stub.Finish()
+	assert.Must(t).True(!stub.IsFailed)
+}
+
+func TestT_LogVarGraph(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	a := testcase.LetValue(s, `a`, 1)
+	b := testcase.Let(s, `b`, func(t *testcase.T) int { return a.Get(t) + 1 })
+	c := testcase.Let(s, `c`, func(t *testcase.T) int { return a.Get(t) + b.Get(t) })
+	s.Test(``, func(t *testcase.T) {
+		_ = c.Get(t)
+		t.LogVarGraph()
+	})
+	stub.Finish()
+	assert.Must(t).True(!stub.IsFailed)
+	logs := strings.Join(stub.Logs, "\n")
+	assert.Must(t).Contain(logs, `variable dependency graph:`)
+	assert.Must(t).Contain(logs, `c (Var_test.go:`)
+	assert.Must(t).Contain(logs, `) -> a, b`)
+	assert.Must(t).Contain(logs, "\ta (Var_test.go:")
+	assert.Must(t).True(strings.HasSuffix(logs, `) -> a`))
+}
+
+func TestVar_LetSuper(t *testing.T) {
+	type User struct {
+		Name string
+		Role string
+	}
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	var superCalls int
+	user := testcase.Let(s, `user`, func(t *testcase.T) User {
+		superCalls++
+		return User{Name: `Jane`, Role: `user`}
+	})
+	var outer, nested User
+	s.Test(`outer`, func(t *testcase.T) { outer = user.Get(t) })
+	s.When(`user is an admin`, func(s *testcase.Spec) {
+		user.LetSuper(s, func(t *testcase.T, super func() User) User {
+			u := super()
+			u.Role = `admin`
+			_ = super()
+			return u
+		})
+		s.Test(`nested`, func(t *testcase.T) { nested = user.Get(t) })
+	})
+	stub.Finish()
+	assert.Must(t).True(!stub.IsFailed)
+	assert.Must(t).Equal(User{Name: `Jane`, Role: `user`}, outer)
+	assert.Must(t).Equal(User{Name: `Jane`, Role: `admin`}, nested)
+	assert.Must(t).Equal(2, superCalls)
+}
+
+func TestVar_LetSuper_withInit(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	v := testcase.VarOf[int]{Name: `v`, Init: func(t *testcase.T) int { return 41 }}
+	v.LetSuper(s, func(t *testcase.T, super func() int) int { return super() + 1 })
+	var got int
+	s.Test(``, func(t *testcase.T) { got = v.Get(t) })
+	stub.Finish()
+	assert.Must(t).True(!stub.IsFailed)
+	assert.Must(t).Equal(42, got)
+}
+
+func TestVar_LetSuper_withoutParentDefinition(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := testcase.NewSpec(stub)
+	v := testcase.VarOf[int]{Name: `v`}
+	var finished bool
+	internal.RecoverExceptGoexit(func() {
+		v.LetSuper(s, func(t *testcase.T, super func() int) int { return super() })
+		finished = true
+	})
+	assert.Must(t).True(!finished)
+	assert.Must(t).True(stub.IsFailed)
+	assert.Must(t).Contain(strings.Join(stub.Logs, "\n"), `v Var has no definition in the outer scopes`)
+}
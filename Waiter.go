@@ -3,6 +3,8 @@ package testcase
 import (
 	"runtime"
 	"time"
+
+	"github.com/adamluzsi/testcase/clock"
 )
 
 // Waiter is a component that waits for a time, event, or opportunity.
@@ -12,13 +14,33 @@ type Waiter struct {
 	// WaitTimeout is used to calculate the deadline for the Waiter.While call.
 	// If the retry takes longer than the WaitTimeout, the retry will be cancelled.
 	WaitTimeout time.Duration
+	// Clock is used to tell the time during the wait.
+	// By default, the real time is used.
+	//
+	// When the Clock is a *clock.Fake (e.g.: T.Clock), Waiter.Wait advances the fake time instead of waiting,
+	// so the time-dependent code under test can progress, without waiting real time.
+	// With a zero WaitDuration, the fake time is advanced with a millisecond, so the WaitTimeout can be reached.
+	// Keep in mind, that the fake clock is shared, thus when it is the T.Clock,
+	// every wait moves the test's own time forward as well, and a While call can advance it up to the WaitTimeout.
+	Clock clock.Clock
 }
 
+// fakeClockMinWait is how much a fake clock is advanced at least during a wait.
+const fakeClockMinWait = time.Millisecond
+
 // Wait will attempt to wait a bit and leave breathing space for other goroutines to steal processing time.
 // It will also attempt to schedule other goroutines.
 func (w Waiter) Wait() {
-	finishTime := time.Now().Add(w.WaitDuration)
-	for time.Now().Before(finishTime) {
+	if fake, ok := w.Clock.(*clock.Fake); ok {
+		d := w.WaitDuration
+		if d < fakeClockMinWait {
+			d = fakeClockMinWait
+		}
+		advanceFakeClock(fake, d)
+		return
+	}
+	finishTime := clockNow(w.Clock).Add(w.WaitDuration)
+	for clockNow(w.Clock).Before(finishTime) {
 		runtime.Gosched()
 		time.Sleep(time.Nanosecond)
 	}
@@ -28,8 +50,36 @@ func (w Waiter) Wait() {
 // By default, if the timeout is not defined, it just attempts to execute the condition once.
 // Calling multiple times the condition function should be a safe operation.
 func (w Waiter) While(condition func() bool) {
-	finishTime := time.Now().Add(w.WaitTimeout)
-	for condition() && time.Now().Before(finishTime) {
+	finishTime := clockNow(w.Clock).Add(w.WaitTimeout)
+	for condition() && clockNow(w.Clock).Before(finishTime) {
 		w.Wait()
 	}
 }
+
+func clockNow(c clock.Clock) time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// clockSleep waits for the duration on the clock,
+// except for a fake clock, which is advanced instead.
+func clockSleep(c clock.Clock, d time.Duration) {
+	switch c := c.(type) {
+	case nil:
+		time.Sleep(d)
+	case *clock.Fake:
+		advanceFakeClock(c, d)
+	default:
+		c.Sleep(d)
+	}
+}
+
+// advanceFakeClock moves the fake clock forward,
+// and gives a chance to the goroutines that wait for the fired timers to run.
+func advanceFakeClock(c *clock.Fake, d time.Duration) {
+	c.Advance(d)
+	runtime.Gosched()
+	time.Sleep(time.Nanosecond)
+}
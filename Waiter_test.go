@@ -7,6 +7,7 @@ import (
 
 	"github.com/adamluzsi/testcase"
 	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/clock"
 	"github.com/adamluzsi/testcase/fixtures"
 )
 
@@ -192,3 +193,29 @@ func TestWaiter(t *testing.T) {
 func BenchmarkWaiter(b *testing.B) {
 	SpecWaiter(b)
 }
+
+func TestWaiter_withFakeClock(t *testing.T) {
+	c := clock.NewFake(time.Now())
+	c.Freeze()
+	begin := c.Now()
+	w := testcase.Waiter{WaitDuration: time.Second, WaitTimeout: time.Minute, Clock: c}
+
+	start := time.Now()
+	var count int
+	w.While(func() bool {
+		count++
+		return true
+	})
+	assert.Must(t).Equal(61, count)
+	assert.Must(t).Equal(begin.Add(time.Minute), c.Now())
+	assert.Must(t).True(time.Since(start) < 30*time.Second, `it should not wait in real time`)
+
+	t.Log(`with zero wait duration, the fake time still advances`)
+	w = testcase.Waiter{WaitTimeout: 10 * time.Millisecond, Clock: c}
+	count = 0
+	w.While(func() bool {
+		count++
+		return true
+	})
+	assert.Must(t).Equal(11, count)
+}
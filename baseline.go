@@ -0,0 +1,198 @@
+package testcase
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// benchmarkSampleCount is the number of samples that a benchmark run is split into,
+// when the benchmark has enough iterations.
+const benchmarkSampleCount = 5
+
+const defaultBenchmarkTolerance = 10
+
+// benchmarkSample is the per-operation result of a part of the benchmark iterations.
+type benchmarkSample struct {
+	NsPerOp     float64
+	BytesPerOp  float64
+	AllocsPerOp float64
+}
+
+// benchmarkStats is the format of a benchmark in the baseline file.
+type benchmarkStats struct {
+	NsPerOp     []float64 `json:"ns_per_op"`
+	BytesPerOp  []float64 `json:"bytes_per_op"`
+	AllocsPerOp []float64 `json:"allocs_per_op"`
+}
+
+func (bs *benchmarkStats) add(s benchmarkSample) {
+	bs.NsPerOp = append(bs.NsPerOp, s.NsPerOp)
+	bs.BytesPerOp = append(bs.BytesPerOp, s.BytesPerOp)
+	bs.AllocsPerOp = append(bs.AllocsPerOp, s.AllocsPerOp)
+}
+
+func (bs benchmarkStats) isEmpty() bool {
+	return len(bs.NsPerOp) == 0
+}
+
+func getBenchBaselinePath() (string, bool) {
+	path, ok := os.LookupEnv(EnvKeyBenchBaseline)
+	return path, ok && path != ``
+}
+
+func isBenchBaselineUpdate() bool {
+	raw, ok := os.LookupEnv(EnvKeyBenchUpdate)
+	if !ok || raw == `` {
+		return false
+	}
+	update, err := strconv.ParseBool(raw)
+	return err != nil || update
+}
+
+func (spec *Spec) lookupBenchmarkTolerance() float64 {
+	spec.testingTB.Helper()
+	specs := spec.list()
+	for i := len(specs) - 1; 0 <= i; i-- {
+		if specs[i].benchmarkTolerance != nil {
+			return *specs[i].benchmarkTolerance
+		}
+	}
+	return defaultBenchmarkTolerance
+}
+
+// checkBenchmarkBaseline either records the result of the finished benchmark into the baseline,
+// or compares the result against the baseline and fails on a regression.
+func (spec *Spec) checkBenchmarkBaseline(tb testing.TB) {
+	spec.testingTB.Helper()
+	tb.Helper()
+	path, ok := getBenchBaselinePath()
+	if !ok || spec.benchmarkStats.isEmpty() {
+		return
+	}
+	if isBenchBaselineUpdate() {
+		if err := updateBenchBaseline(path, spec.id, spec.benchmarkStats); err != nil {
+			tb.Errorf(`unable to update the benchmark baseline (%s): %s`, path, err.Error())
+		}
+		return
+	}
+	baseline, err := readBenchBaseline(path)
+	if err != nil {
+		tb.Errorf(`unable to read the benchmark baseline (%s): %s`, path, err.Error())
+		return
+	}
+	base, ok := baseline[spec.id]
+	if !ok {
+		return
+	}
+	if report, ok := benchmarkRegressionReport(spec.descriptionPathLine(), base, spec.benchmarkStats, spec.lookupBenchmarkTolerance()); ok {
+		tb.Error(report)
+	}
+}
+
+// benchmarkRegressionReport compares the metrics of the benchmark with the baseline.
+// A metric regressed when its median exceeds the baseline's median more than the tolerance,
+// and even the best sample is worse than every sample of the baseline,
+// so a difference within the noise of the measurements is not reported.
+func benchmarkRegressionReport(desc string, base, current benchmarkStats, tolerance float64) (string, bool) {
+	var lines []string
+	for _, m := range []struct {
+		unit          string
+		base, current []float64
+	}{
+		{unit: `ns/op`, base: base.NsPerOp, current: current.NsPerOp},
+		{unit: `B/op`, base: base.BytesPerOp, current: current.BytesPerOp},
+		{unit: `allocs/op`, base: base.AllocsPerOp, current: current.AllocsPerOp},
+	} {
+		if len(m.base) == 0 || len(m.current) == 0 {
+			continue
+		}
+		baseMedian, currentMedian := median(m.base), median(m.current)
+		if currentMedian <= baseMedian*(1+tolerance/100) || minOf(m.current) <= maxOf(m.base) {
+			continue
+		}
+		line := fmt.Sprintf(`  %s: %.2f -> %.2f`, m.unit, baseMedian, currentMedian)
+		if 0 < baseMedian {
+			line += fmt.Sprintf(` (+%.1f%%)`, (currentMedian-baseMedian)/baseMedian*100)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return ``, false
+	}
+	return fmt.Sprintf("benchmark regression in %s (tolerance: %g%%):\n%s", desc, tolerance, strings.Join(lines, "\n")), true
+}
+
+func median(vs []float64) float64 {
+	sorted := append([]float64{}, vs...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func minOf(vs []float64) float64 {
+	min := vs[0]
+	for _, v := range vs {
+		if v < min {
+			min = v
+		}
+	}
+	return min
+}
+
+func maxOf(vs []float64) float64 {
+	max := vs[0]
+	for _, v := range vs {
+		if max < v {
+			max = v
+		}
+	}
+	return max
+}
+
+var benchBaselineMutex sync.Mutex
+
+func readBenchBaseline(path string) (map[string]benchmarkStats, error) {
+	benchBaselineMutex.Lock()
+	defer benchBaselineMutex.Unlock()
+	return readBenchBaselineFile(path)
+}
+
+func readBenchBaselineFile(path string) (map[string]benchmarkStats, error) {
+	baseline := make(map[string]benchmarkStats)
+	bs, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return baseline, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(bs, &baseline); err != nil {
+		return nil, err
+	}
+	return baseline, nil
+}
+
+func updateBenchBaseline(path, id string, stats benchmarkStats) error {
+	benchBaselineMutex.Lock()
+	defer benchBaselineMutex.Unlock()
+	baseline, err := readBenchBaselineFile(path)
+	if err != nil {
+		return err
+	}
+	baseline[id] = stats
+	bs, err := json.MarshalIndent(baseline, ``, `  `)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, bs, 0644)
+}
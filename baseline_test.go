@@ -0,0 +1,108 @@
+package testcase
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal"
+)
+
+func TestBenchmarkRegressionReport(t *testing.T) {
+	base := benchmarkStats{
+		NsPerOp:     []float64{100, 102, 98, 101, 99},
+		BytesPerOp:  []float64{64, 64, 64, 64, 64},
+		AllocsPerOp: []float64{1, 1, 1, 1, 1},
+	}
+
+	t.Run(`within the tolerance, no regression is reported`, func(t *testing.T) {
+		current := benchmarkStats{
+			NsPerOp:     []float64{105, 108, 106, 107, 104},
+			BytesPerOp:  []float64{64, 64, 64, 64, 64},
+			AllocsPerOp: []float64{1, 1, 1, 1, 1},
+		}
+		_, ok := benchmarkRegressionReport(`desc`, base, current, 10)
+		assert.Must(t).True(!ok)
+	})
+
+	t.Run(`when the samples overlap with the baseline, the difference is considered noise`, func(t *testing.T) {
+		current := benchmarkStats{NsPerOp: []float64{97, 150, 160, 170, 180}}
+		_, ok := benchmarkRegressionReport(`desc`, base, current, 10)
+		assert.Must(t).True(!ok)
+	})
+
+	t.Run(`above the tolerance, the regressed metrics are reported`, func(t *testing.T) {
+		current := benchmarkStats{
+			NsPerOp:     []float64{150, 151, 149, 150, 152},
+			BytesPerOp:  []float64{128, 128, 128, 128, 128},
+			AllocsPerOp: []float64{1, 1, 1, 1, 1},
+		}
+		report, ok := benchmarkRegressionReport(`A/B`, base, current, 10)
+		assert.Must(t).True(ok)
+		assert.Must(t).Contain(report, `benchmark regression in A/B (tolerance: 10%)`)
+		assert.Must(t).Contain(report, `ns/op: 100.00 -> 150.00 (+50.0%)`)
+		assert.Must(t).Contain(report, `B/op: 64.00 -> 128.00 (+100.0%)`)
+		assert.Must(t).True(!strings.Contains(report, `allocs/op`), report)
+	})
+}
+
+func TestBenchmarkBaseline_file(t *testing.T) {
+	path := filepath.Join(t.TempDir(), `bench.json`)
+
+	baseline, err := readBenchBaseline(path)
+	assert.Must(t).Nil(err)
+	assert.Must(t).Equal(0, len(baseline))
+
+	a := benchmarkStats{NsPerOp: []float64{1, 2}, BytesPerOp: []float64{0, 0}, AllocsPerOp: []float64{0, 0}}
+	b := benchmarkStats{NsPerOp: []float64{3}, BytesPerOp: []float64{8}, AllocsPerOp: []float64{1}}
+	assert.Must(t).Nil(updateBenchBaseline(path, `A`, a))
+	assert.Must(t).Nil(updateBenchBaseline(path, `B`, b))
+
+	baseline, err = readBenchBaseline(path)
+	assert.Must(t).Nil(err)
+	assert.Must(t).Equal(map[string]benchmarkStats{`A`: a, `B`: b}, baseline)
+}
+
+func TestSpec_checkBenchmarkBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), `bench.json`)
+	SetEnv(t, EnvKeyBenchBaseline, path)
+
+	var sub *Spec
+	SetEnv(t, EnvKeyBenchUpdate, `1`)
+	runBenchmark(t, func(s *Spec) { sub = s }, func(t *T) {})
+	assert.Must(t).Equal(benchmarkSampleCount, len(sub.benchmarkStats.NsPerOp))
+	stub := &internal.StubTB{}
+	sub.checkBenchmarkBaseline(stub)
+	assert.Must(t).True(!stub.IsFailed)
+
+	baseline, err := readBenchBaseline(path)
+	assert.Must(t).Nil(err)
+	assert.Must(t).Equal(sub.benchmarkStats, baseline[`BenchmarkSubject/benchmark`])
+
+	SetEnv(t, EnvKeyBenchUpdate, ``)
+	regressed := benchmarkStats{}
+	for _, ns := range baseline[sub.id].NsPerOp {
+		regressed.NsPerOp = append(regressed.NsPerOp, ns*100+1000)
+	}
+	sub.benchmarkStats = regressed
+	stub = &internal.StubTB{}
+	sub.checkBenchmarkBaseline(stub)
+	assert.Must(t).True(stub.IsFailed)
+	assert.Must(t).Contain(strings.Join(stub.Logs, "\n"), `benchmark regression in`)
+
+	BenchmarkTolerance(1000000).setup(sub)
+	stub = &internal.StubTB{}
+	sub.checkBenchmarkBaseline(stub)
+	assert.Must(t).True(!stub.IsFailed)
+}
+
+func TestBenchmarkTolerance(t *testing.T) {
+	s := NewSpec(&internal.StubTB{})
+	assert.Must(t).Equal(float64(defaultBenchmarkTolerance), s.lookupBenchmarkTolerance())
+	s.Context(``, func(s *Spec) {
+		s.Context(``, func(s *Spec) {
+			assert.Must(t).Equal(float64(25), s.lookupBenchmarkTolerance())
+		})
+	}, BenchmarkTolerance(25))
+}
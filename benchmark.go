@@ -0,0 +1,211 @@
+package testcase
+
+import (
+	"math/rand"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+// benchmark is the state of the benchmark that executes the test.
+type benchmark struct {
+	b        *testing.B
+	timer    *benchmarkTimer
+	parallel bool
+	// probing is set while the test block is executed to find out whether it uses T.Measure.
+	probing bool
+	// measured is set when the test block uses T.Measure.
+	measured bool
+	// mutex protects the testing.B between the goroutines of a parallel benchmark.
+	mutex sync.Mutex
+}
+
+// Measure marks the region of the test that should be measured during a benchmark.
+// When Measure is used, the rest of the test block is excluded from the benchmark timing.
+// Outside of benchmarks, or with ParallelBenchmark, it simply executes the block.
+func (t *T) Measure(blk func()) {
+	t.TB.Helper()
+	if t.benchmark == nil || t.benchmark.parallel {
+		blk()
+		return
+	}
+	t.benchmark.measured = true
+	if t.benchmark.probing {
+		blk()
+		return
+	}
+	t.benchmark.timer.start()
+	defer t.benchmark.timer.stop()
+	blk()
+}
+
+// ReportMetric adds "n unit" to the reported benchmark results.
+// Since the test block runs b.N times, the last reported value is used,
+// thus the metric should be a per-operation value, like "bytes/op".
+// Outside of benchmarks, ReportMetric does nothing.
+func (t *T) ReportMetric(n float64, unit string) {
+	t.TB.Helper()
+	if t.benchmark == nil {
+		return
+	}
+	t.benchmark.mutex.Lock()
+	defer t.benchmark.mutex.Unlock()
+	t.benchmark.b.ReportMetric(n, unit)
+}
+
+// benchmarkGen returns the generator for a Property test's benchmark, seeded with the test seed.
+// Outside of Property tests, it returns nil.
+func (spec *Spec) benchmarkGen() *Gen {
+	if !spec.property {
+		return nil
+	}
+	return newGen(rand.NewSource(spec.testSeed()), nil)
+}
+
+func (spec *Spec) isReportingAllocs() bool {
+	spec.testingTB.Helper()
+	for _, context := range spec.list() {
+		if context.reportAllocs {
+			return true
+		}
+	}
+	return false
+}
+
+func (spec *Spec) isParallelBenchmark() bool {
+	spec.testingTB.Helper()
+	for _, context := range spec.list() {
+		if context.parallelBenchmark {
+			return true
+		}
+	}
+	return false
+}
+
+// benchmark executes the test block b.N times, excluding the hooks and the Let initializations from the timing.
+// When the test block uses T.Measure, only the measured region is timed.
+// To know that before the timing starts, the test block is executed once more without being timed.
+//
+// When a benchmark baseline is used, the iterations are split into samples,
+// so the comparison with the baseline can take the noise of the measurement into account.
+func (spec *Spec) benchmark(b *testing.B, t *T, blk func(*T)) {
+	spec.testingTB.Helper()
+	b.Helper()
+	_, tracked := getBenchBaselinePath()
+	timer := &benchmarkTimer{b: b, tracked: tracked}
+	t.benchmark = &benchmark{b: b, timer: timer, probing: true}
+	func() {
+		timer.stop()
+		b.Helper()
+		defer t.setUp()()
+		blk(t)
+	}()
+	t.benchmark.probing = false
+	samples := benchmarkSampleCount
+	if b.N < samples {
+		samples = 1
+	}
+	var stats benchmarkStats
+	for sample, done := 0, 0; sample < samples; sample++ {
+		n := b.N / samples
+		if sample == samples-1 {
+			n = b.N - done
+		}
+		for i := 0; i < n; i++ {
+			func() {
+				timer.stop()
+				b.Helper()
+				defer t.setUp()()
+				if !t.benchmark.measured {
+					timer.start()
+				}
+				blk(t)
+				timer.stop()
+			}()
+		}
+		done += n
+		stats.add(timer.sample(n))
+	}
+	spec.benchmarkStats = stats
+}
+
+// benchmarkParallel executes the test block with testing.B.RunParallel.
+// Each goroutine has its own T and generator, thus the Let variables are initialized per goroutine.
+// Since the timer can't be stopped in a parallel benchmark, the hooks are part of the timing.
+func (spec *Spec) benchmarkParallel(b *testing.B, blk func(*T)) {
+	spec.testingTB.Helper()
+	b.Helper()
+	bm := &benchmark{b: b, parallel: true}
+	b.RunParallel(func(pb *testing.PB) {
+		t := newT(b, spec)
+		t.gen = spec.benchmarkGen()
+		t.benchmark = bm
+		for pb.Next() {
+			func() {
+				defer t.setUp()()
+				blk(t)
+			}()
+		}
+	})
+}
+
+// benchmarkTimer controls the timer of the testing.B,
+// and when tracking is enabled, it measures the timed regions for the benchmark baseline as well.
+type benchmarkTimer struct {
+	b       *testing.B
+	tracked bool
+	running bool
+
+	begin    time.Time
+	beginMem runtime.MemStats
+	duration time.Duration
+	allocs   uint64
+	bytes    uint64
+}
+
+func (bt *benchmarkTimer) start() {
+	if bt.running {
+		return
+	}
+	bt.running = true
+	// runtime.ReadMemStats stops the world, thus it is called outside of the timed region.
+	if bt.tracked {
+		runtime.ReadMemStats(&bt.beginMem)
+	}
+	bt.b.StartTimer()
+	if bt.tracked {
+		bt.begin = time.Now()
+	}
+}
+
+func (bt *benchmarkTimer) stop() {
+	if !bt.running {
+		bt.b.StopTimer()
+		return
+	}
+	bt.running = false
+	if bt.tracked {
+		bt.duration += time.Since(bt.begin)
+	}
+	bt.b.StopTimer()
+	if bt.tracked {
+		var mem runtime.MemStats
+		runtime.ReadMemStats(&mem)
+		bt.allocs += mem.Mallocs - bt.beginMem.Mallocs
+		bt.bytes += mem.TotalAlloc - bt.beginMem.TotalAlloc
+	}
+}
+
+// sample returns the per-operation results of the last n iterations.
+func (bt *benchmarkTimer) sample(n int) benchmarkSample {
+	defer func() { bt.duration, bt.allocs, bt.bytes = 0, 0, 0 }()
+	if n == 0 {
+		return benchmarkSample{}
+	}
+	return benchmarkSample{
+		NsPerOp:     float64(bt.duration.Nanoseconds()) / float64(n),
+		BytesPerOp:  float64(bt.bytes) / float64(n),
+		AllocsPerOp: float64(bt.allocs) / float64(n),
+	}
+}
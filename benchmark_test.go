@@ -0,0 +1,126 @@
+package testcase
+
+import (
+	"flag"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal"
+)
+
+// runBenchmark executes the test block as a benchmark of a spec with the given options.
+func runBenchmark(tb testing.TB, context func(s *Spec), blk func(*T), opts ...SpecOption) testing.BenchmarkResult {
+	return runBenchmarkFor(tb, `10x`, context, blk, opts...)
+}
+
+// runBenchmarkFor executes the test block as a benchmark with the given -test.benchtime value.
+func runBenchmarkFor(tb testing.TB, benchtimeValue string, context func(s *Spec), blk func(*T), opts ...SpecOption) testing.BenchmarkResult {
+	benchtime := flag.Lookup(`test.benchtime`)
+	og := benchtime.Value.String()
+	assert.Must(tb).Nil(benchtime.Value.Set(benchtimeValue))
+	defer func() { _ = benchtime.Value.Set(og) }()
+
+	s := NewSpec(&internal.StubTB{StubName: `BenchmarkSubject`})
+	sub := s.newSubSpec(`benchmark`, opts...)
+	sub.id = sub.testID()
+	sub.result = newTestResult()
+	if context != nil {
+		context(sub)
+	}
+	return testing.Benchmark(func(b *testing.B) { sub.runB(b, blk) })
+}
+
+func TestT_Measure(t *testing.T) {
+	t.Run(`during a benchmark, only the measured region is timed`, func(t *testing.T) {
+		res := runBenchmark(t, nil, func(t *T) {
+			time.Sleep(20 * time.Millisecond)
+			t.Measure(func() {})
+		})
+		assert.Must(t).True(res.NsPerOp() < int64(10*time.Millisecond), res.String())
+	})
+
+	t.Run(`during a benchmark, the unmeasured region of the first iteration is not timed either`, func(t *testing.T) {
+		res := runBenchmarkFor(t, `1x`, nil, func(t *T) {
+			time.Sleep(50 * time.Millisecond)
+			t.Measure(func() {})
+		})
+		assert.Must(t).True(res.NsPerOp() < int64(time.Millisecond), res.String())
+	})
+
+	t.Run(`outside of benchmarks, it executes the block`, func(t *testing.T) {
+		tct := NewT(&internal.StubTB{}, nil)
+		var ran bool
+		tct.Measure(func() { ran = true })
+		assert.Must(t).True(ran)
+	})
+}
+
+func TestT_ReportMetric(t *testing.T) {
+	res := runBenchmark(t, nil, func(t *T) {
+		t.ReportMetric(42, `things/op`)
+	})
+	assert.Must(t).Equal(float64(42), res.Extra[`things/op`])
+
+	NewT(&internal.StubTB{}, nil).ReportMetric(42, `things/op`) // no-op outside of benchmarks
+}
+
+func TestReportAllocs(t *testing.T) {
+	s := NewSpec(&internal.StubTB{})
+	s.Context(``, func(s *Spec) {
+		s.Context(``, func(s *Spec) {
+			assert.Must(t).True(s.isReportingAllocs())
+		})
+	}, ReportAllocs())
+	assert.Must(t).True(!s.isReportingAllocs())
+}
+
+func TestParallelBenchmark(t *testing.T) {
+	var (
+		mutex   sync.Mutex
+		threads = make(map[*T]struct{})
+		inits   int32
+		runs    int32
+	)
+	res := runBenchmark(t, func(s *Spec) {
+		s.Let(`counter`, func(t *T) interface{} {
+			atomic.AddInt32(&inits, 1)
+			return 0
+		})
+	}, func(t *T) {
+		mutex.Lock()
+		threads[t] = struct{}{}
+		mutex.Unlock()
+		t.Measure(func() {}) // no-op in parallel benchmarks
+		atomic.AddInt32(&runs, 1)
+		_ = t.I(`counter`)
+	}, ParallelBenchmark())
+	assert.Must(t).True(0 < res.N, res.String())
+	assert.Must(t).True(1 < len(threads) || res.N == 1)
+	assert.Must(t).Equal(atomic.LoadInt32(&runs), atomic.LoadInt32(&inits))
+}
+
+func TestParallelBenchmark_property(t *testing.T) {
+	var missing int32
+	runBenchmark(t, func(s *Spec) { s.property = true }, func(t *T) {
+		if t.gen == nil {
+			atomic.AddInt32(&missing, 1)
+			return
+		}
+		_ = t.gen.Int()
+	}, ParallelBenchmark())
+	assert.Must(t).Equal(int32(0), atomic.LoadInt32(&missing))
+}
+
+func TestSpec_benchmark_clockIsResetBetweenIterations(t *testing.T) {
+	var carried int32
+	runBenchmark(t, nil, func(t *T) {
+		if time.Hour < t.Clock.Now().Sub(time.Now()) {
+			atomic.AddInt32(&carried, 1)
+		}
+		t.Clock.Advance(24 * time.Hour)
+	})
+	assert.Must(t).Equal(int32(0), atomic.LoadInt32(&carried))
+}
@@ -43,3 +43,35 @@ func (spec *Spec) callerLocationName(skip int) string {
 	}
 	return ""
 }
+
+// callerPackagePath returns the import path of the package that uses testcase.
+func callerPackagePath() string {
+	for i := 0; i < 1024; i++ {
+		pc, file, _, ok := runtime.Caller(1 + i)
+		if !ok {
+			break
+		}
+		if !strings.HasSuffix(file, `_test.go`) &&
+			(strings.HasPrefix(file, testcasePkgDirPath) ||
+				strings.Contains(file, `go/src/testing/`) ||
+				strings.Contains(file, `go/src/runtime/`)) {
+			continue
+		}
+		fn := runtime.FuncForPC(pc)
+		if fn == nil {
+			continue
+		}
+		return packagePathOfFunc(fn.Name())
+	}
+	return `unknown`
+}
+
+// packagePathOfFunc extracts the package path from a fully qualified function name,
+// e.g.: "github.com/foo/bar_test.TestBaz.func1" -> "github.com/foo/bar"
+func packagePathOfFunc(name string) string {
+	lastSlash := strings.LastIndex(name, `/`)
+	if dot := strings.Index(name[lastSlash+1:], `.`); 0 <= dot {
+		name = name[:lastSlash+1+dot]
+	}
+	return strings.TrimSuffix(name, `_test`)
+}
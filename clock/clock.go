@@ -0,0 +1,62 @@
+// Package clock provides an abstraction over the time functions of the time package,
+// so the time-dependent code can be tested deterministically with a Fake clock.
+package clock
+
+import "time"
+
+// Clock tells the time, and notifies about the passing of the time.
+// The code under test should use a Clock instead of the time package,
+// so the tests can control the time with a Fake clock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// NewTimer creates a new Timer that will send the current time on its channel after at least duration d.
+	NewTimer(d time.Duration) Timer
+	// NewTicker returns a new Ticker that sends the current time on its channel after each tick,
+	// with a period specified by the duration argument.
+	// The duration must be greater than zero, or else NewTicker will panic.
+	NewTicker(d time.Duration) Ticker
+	// Sleep pauses the current goroutine for at least the duration d.
+	Sleep(d time.Duration)
+}
+
+// Timer represents a single event, like time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+	// Stop prevents the Timer from firing.
+	// It returns true if the call stops the timer, false if the timer has already expired or been stopped.
+	Stop() bool
+	// Reset changes the timer to expire after duration d.
+	// It returns true if the timer had been active, false if the timer had expired or been stopped.
+	Reset(d time.Duration) bool
+}
+
+// Ticker holds a channel that delivers ticks of a clock at intervals, like time.Ticker.
+type Ticker interface {
+	// C returns the channel on which the ticks are delivered.
+	C() <-chan time.Time
+	// Stop turns off the ticker. After Stop, no more ticks will be sent.
+	Stop()
+	// Reset stops the ticker and resets its period to the specified duration.
+	Reset(d time.Duration)
+}
+
+// Real is the Clock of the time package.
+type Real struct{}
+
+func (Real) Now() time.Time                         { return time.Now() }
+func (Real) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (Real) NewTimer(d time.Duration) Timer         { return realTimer{Timer: time.NewTimer(d)} }
+func (Real) NewTicker(d time.Duration) Ticker       { return realTicker{Ticker: time.NewTicker(d)} }
+func (Real) Sleep(d time.Duration)                  { time.Sleep(d) }
+
+type realTimer struct{ *time.Timer }
+
+func (t realTimer) C() <-chan time.Time { return t.Timer.C }
+
+type realTicker struct{ *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.Ticker.C }
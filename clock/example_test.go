@@ -0,0 +1,27 @@
+package clock_test
+
+import (
+	"time"
+
+	"github.com/adamluzsi/testcase/clock"
+)
+
+func ExampleFake() {
+	c := clock.NewFake(time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC))
+	c.Freeze()
+
+	timer := c.NewTimer(time.Hour)
+	c.Advance(time.Hour)
+	<-timer.C() // fired, without waiting an hour
+}
+
+func ExampleFake_Travel() {
+	c := clock.NewFake(time.Now())
+	c.Travel(time.Date(2038, time.January, 19, 3, 14, 7, 0, time.UTC))
+	_ = c.Now() // the time flows from 2038-01-19T03:14:07Z
+}
+
+func ExampleReal() {
+	var c clock.Clock = clock.Real{}
+	_ = c.Now()
+}
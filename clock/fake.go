@@ -0,0 +1,244 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// NewFake returns a Fake clock, which starts from the given time.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now, anchor: time.Now()}
+}
+
+// Fake is a Clock that can be controlled by the test.
+//
+// By default, the time of a Fake clock flows as the real time does, starting from the time it was created with.
+// With Travel, the clock jumps to a given time, and with Advance, it moves forward with a given duration,
+// while with Freeze, the flow of time stops until Unfreeze is called.
+//
+// The timers and tickers created by the Fake clock fire when the fake time reaches their deadline,
+// and when the clock moves forward with Advance or Travel, the pending timers fire in the order of their deadline.
+// Sleep blocks until the fake time reaches the wake-up time,
+// thus a goroutine that sleeps on a frozen clock waits until the clock is advanced.
+//
+// It is safe to use a Fake clock from multiple goroutines.
+type Fake struct {
+	mutex sync.Mutex
+	// now is the fake time at the moment of the anchor.
+	now time.Time
+	// anchor is the real time when the fake time was last set.
+	anchor time.Time
+	frozen bool
+	timers []*fakeTimer
+	// seq keeps the timers with the same deadline in the order of their creation.
+	seq    int
+	wakeup *time.Timer
+}
+
+func (f *Fake) Now() time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.current()
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	t := &fakeTimer{clock: f, c: make(chan time.Time, 1)}
+	f.start(t, d)
+	return t
+}
+
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic(`non-positive interval for clock.Fake.NewTicker`)
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	t := &fakeTimer{clock: f, c: make(chan time.Time, 1), period: d}
+	f.start(t, d)
+	return fakeTicker{fakeTimer: t}
+}
+
+func (f *Fake) Sleep(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	<-f.After(d)
+}
+
+// Travel sets the clock's time to the given time.
+// When the clock moves forward, the timers with a deadline before the new time fire in order.
+// Travel doesn't change whether the clock is frozen.
+func (f *Fake) Travel(to time.Time) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.fireUntil(to)
+	f.schedule()
+}
+
+// Advance moves the clock's time forward with the given duration,
+// and the timers with a deadline within the duration fire in order.
+func (f *Fake) Advance(d time.Duration) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.fireUntil(f.current().Add(d))
+	f.schedule()
+}
+
+// Freeze stops the flow of time, until Unfreeze is called.
+// The time of a frozen clock only changes with Travel and Advance.
+func (f *Fake) Freeze() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.set(f.current())
+	f.frozen = true
+	f.schedule()
+}
+
+// Unfreeze lets the time flow again from the time where it was frozen.
+func (f *Fake) Unfreeze() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.set(f.current())
+	f.frozen = false
+	f.schedule()
+}
+
+func (f *Fake) current() time.Time {
+	if f.frozen {
+		return f.now
+	}
+	return f.now.Add(time.Since(f.anchor))
+}
+
+func (f *Fake) set(now time.Time) {
+	f.now = now
+	f.anchor = time.Now()
+}
+
+func (f *Fake) start(t *fakeTimer, d time.Duration) {
+	f.seq++
+	t.seq = f.seq
+	t.deadline = f.current().Add(d)
+	if !t.active {
+		t.active = true
+		f.timers = append(f.timers, t)
+	}
+	f.fireUntil(f.current())
+	f.schedule()
+}
+
+func (f *Fake) stop(t *fakeTimer) bool {
+	if !t.active {
+		return false
+	}
+	t.active = false
+	for i, other := range f.timers {
+		if other == t {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			break
+		}
+	}
+	f.schedule()
+	return true
+}
+
+// fireUntil fires the timers with a deadline before the given time in the order of their deadline,
+// and then sets the clock to the given time.
+func (f *Fake) fireUntil(until time.Time) {
+	for {
+		sort.SliceStable(f.timers, func(i, j int) bool {
+			a, b := f.timers[i], f.timers[j]
+			if a.deadline.Equal(b.deadline) {
+				return a.seq < b.seq
+			}
+			return a.deadline.Before(b.deadline)
+		})
+		if len(f.timers) == 0 || until.Before(f.timers[0].deadline) {
+			break
+		}
+		t := f.timers[0]
+		if f.current().Before(t.deadline) {
+			f.set(t.deadline)
+		}
+		select {
+		case t.c <- t.deadline:
+		default: // like time.Ticker, the ticks are dropped for a slow receiver
+		}
+		if 0 < t.period {
+			t.deadline = t.deadline.Add(t.period)
+			continue
+		}
+		t.active = false
+		f.timers = f.timers[1:]
+	}
+	f.set(until)
+}
+
+// schedule makes sure that the next timer fires in real time, while the time flows.
+func (f *Fake) schedule() {
+	if f.wakeup != nil {
+		f.wakeup.Stop()
+		f.wakeup = nil
+	}
+	if f.frozen || len(f.timers) == 0 {
+		return
+	}
+	next := f.timers[0].deadline
+	for _, t := range f.timers[1:] {
+		if t.deadline.Before(next) {
+			next = t.deadline
+		}
+	}
+	f.wakeup = time.AfterFunc(next.Sub(f.current()), func() {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		f.fireUntil(f.current())
+		f.schedule()
+	})
+}
+
+type fakeTimer struct {
+	clock    *Fake
+	c        chan time.Time
+	deadline time.Time
+	period   time.Duration
+	active   bool
+	seq      int
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.c }
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+	return t.clock.stop(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+	active := t.active
+	t.clock.start(t, d)
+	return active
+}
+
+type fakeTicker struct{ *fakeTimer }
+
+func (t fakeTicker) Stop() { t.fakeTimer.Stop() }
+
+func (t fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic(`non-positive interval for clock.Fake.Ticker.Reset`)
+	}
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+	t.period = d
+	t.clock.start(t.fakeTimer, d)
+}
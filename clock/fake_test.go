@@ -0,0 +1,194 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/clock"
+)
+
+var start = time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+func frozenClock() *clock.Fake {
+	c := clock.NewFake(start)
+	c.Freeze()
+	c.Travel(start)
+	return c
+}
+
+func receive(tb testing.TB, c <-chan time.Time) (time.Time, bool) {
+	tb.Helper()
+	select {
+	case t := <-c:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestFake_Now(t *testing.T) {
+	t.Run(`by default, the time flows from the start time`, func(t *testing.T) {
+		c := clock.NewFake(start)
+		time.Sleep(10 * time.Millisecond)
+		assert.Must(t).True(!c.Now().Before(start.Add(10 * time.Millisecond)))
+		assert.Must(t).True(c.Now().Before(start.Add(time.Minute)))
+	})
+
+	t.Run(`when the clock is frozen, the time doesn't change until it is unfrozen`, func(t *testing.T) {
+		c := frozenClock()
+		frozen := c.Now()
+		time.Sleep(10 * time.Millisecond)
+		assert.Must(t).Equal(frozen, c.Now())
+
+		c.Unfreeze()
+		time.Sleep(10 * time.Millisecond)
+		assert.Must(t).True(c.Now().After(frozen))
+		assert.Must(t).True(c.Now().Before(frozen.Add(time.Minute)))
+	})
+
+	t.Run(`when the clock travels, the time continues from the destination`, func(t *testing.T) {
+		c := frozenClock()
+		destination := start.Add(-24 * time.Hour)
+		c.Travel(destination)
+		assert.Must(t).Equal(destination, c.Now())
+	})
+
+	t.Run(`when the clock is advanced, the time moves forward with the duration`, func(t *testing.T) {
+		c := frozenClock()
+		c.Advance(time.Hour)
+		assert.Must(t).Equal(start.Add(time.Hour), c.Now())
+	})
+}
+
+func TestFake_NewTimer(t *testing.T) {
+	t.Run(`the timer fires when the time reaches its deadline`, func(t *testing.T) {
+		c := frozenClock()
+		timer := c.NewTimer(time.Minute)
+		c.Advance(time.Minute - time.Nanosecond)
+		_, ok := receive(t, timer.C())
+		assert.Must(t).True(!ok)
+
+		c.Advance(time.Nanosecond)
+		at, ok := receive(t, timer.C())
+		assert.Must(t).True(ok)
+		assert.Must(t).Equal(start.Add(time.Minute), at)
+		assert.Must(t).True(!timer.Stop())
+	})
+
+	t.Run(`when the timer is stopped, it doesn't fire`, func(t *testing.T) {
+		c := frozenClock()
+		timer := c.NewTimer(time.Minute)
+		assert.Must(t).True(timer.Stop())
+		c.Advance(time.Hour)
+		_, ok := receive(t, timer.C())
+		assert.Must(t).True(!ok)
+	})
+
+	t.Run(`when the timer is reset, it fires after the new duration`, func(t *testing.T) {
+		c := frozenClock()
+		timer := c.NewTimer(time.Minute)
+		assert.Must(t).True(timer.Reset(time.Hour))
+		c.Advance(time.Minute)
+		_, ok := receive(t, timer.C())
+		assert.Must(t).True(!ok)
+		c.Advance(time.Hour)
+		_, ok = receive(t, timer.C())
+		assert.Must(t).True(ok)
+	})
+
+	t.Run(`when the clock is not frozen, the timer fires in real time`, func(t *testing.T) {
+		c := clock.NewFake(start)
+		select {
+		case <-c.After(10 * time.Millisecond):
+		case <-time.After(time.Minute):
+			t.Fatal(`timer didn't fire`)
+		}
+	})
+
+	t.Run(`when the clock moves forward, the pending timers fire in the order of their deadline`, func(t *testing.T) {
+		c := frozenClock()
+		var (
+			third  = c.NewTimer(3 * time.Second)
+			first  = c.NewTimer(time.Second)
+			second = c.NewTimer(2 * time.Second)
+		)
+		c.Travel(start.Add(time.Hour))
+		for i, timer := range []clock.Timer{first, second, third} {
+			at, ok := receive(t, timer.C())
+			assert.Must(t).True(ok)
+			assert.Must(t).Equal(start.Add(time.Duration(i+1)*time.Second), at)
+		}
+		assert.Must(t).Equal(start.Add(time.Hour), c.Now())
+	})
+}
+
+func TestFake_NewTicker(t *testing.T) {
+	c := frozenClock()
+	ticker := c.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	var ticks []time.Time
+	for i := 0; i < 3; i++ {
+		c.Advance(time.Minute)
+		at, ok := receive(t, ticker.C())
+		assert.Must(t).True(ok)
+		ticks = append(ticks, at)
+	}
+	assert.Must(t).Equal([]time.Time{start.Add(time.Minute), start.Add(2 * time.Minute), start.Add(3 * time.Minute)}, ticks)
+
+	t.Log(`like time.Ticker, the ticks are dropped for a slow receiver`)
+	c.Advance(time.Hour)
+	_, ok := receive(t, ticker.C())
+	assert.Must(t).True(ok)
+	_, ok = receive(t, ticker.C())
+	assert.Must(t).True(!ok)
+
+	ticker.Reset(time.Hour)
+	c.Advance(time.Minute)
+	_, ok = receive(t, ticker.C())
+	assert.Must(t).True(!ok)
+
+	ticker.Stop()
+	c.Advance(24 * time.Hour)
+	_, ok = receive(t, ticker.C())
+	assert.Must(t).True(!ok)
+}
+
+func TestFake_Sleep(t *testing.T) {
+	c := frozenClock()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Sleep(time.Hour)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal(`sleep returned before the clock was advanced`)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	for finished := false; !finished; {
+		c.Advance(time.Minute)
+		select {
+		case <-done:
+			finished = true
+		case <-time.After(time.Millisecond):
+		}
+	}
+	assert.Must(t).True(!c.Now().Before(start.Add(time.Hour)))
+}
+
+func TestReal(t *testing.T) {
+	var c clock.Clock = clock.Real{}
+	before := time.Now()
+	c.Sleep(time.Millisecond)
+	<-c.After(time.Millisecond)
+	timer := c.NewTimer(time.Millisecond)
+	<-timer.C()
+	ticker := c.NewTicker(time.Millisecond)
+	<-ticker.C()
+	ticker.Stop()
+	assert.Must(t).True(before.Add(3 * time.Millisecond).Before(c.Now()))
+}
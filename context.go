@@ -0,0 +1,45 @@
+package testcase
+
+import (
+	"context"
+	"sync"
+)
+
+// Context returns a context that is canceled when the test finishes,
+// after the After hooks and the functions deferred with T.Defer are executed,
+// thus they can still use the context for the cleanup.
+// When the Spec has a Timeout, the context's deadline is when the test times out.
+//
+// The context is available in the Let blocks and the hooks as well,
+// which makes it ideal to bound the goroutines started by the testing subject to the lifetime of the test.
+// Retries with Retry.Assert, like T.Eventually, happen within the same context.
+func (t *T) Context() context.Context {
+	return t.context.get()
+}
+
+type testContext struct {
+	mutex sync.RWMutex
+	ctx   context.Context
+}
+
+func (tc *testContext) get() context.Context {
+	tc.mutex.RLock()
+	defer tc.mutex.RUnlock()
+	if tc.ctx == nil {
+		return context.Background()
+	}
+	return tc.ctx
+}
+
+// start replaces the context with a fresh context for the next test execution,
+// and returns the function which cancels it.
+func (t *T) startContext() context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+	if timeout, ok := t.spec.lookupTimeout(); ok && t.benchmark == nil {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	}
+	t.context.mutex.Lock()
+	defer t.context.mutex.Unlock()
+	t.context.ctx = ctx
+	return cancel
+}
@@ -0,0 +1,191 @@
+package testcase
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func getDocOutputDir() (string, bool) {
+	dir, ok := os.LookupEnv(EnvKeyDocOutput)
+	return dir, ok && dir != ``
+}
+
+// writeDocumentation writes the living documentation of the package's specifications
+// into a Markdown and an HTML file in the output directory.
+func writeDocumentation(dir string, pkg *reportedPackage) error {
+	page := newDocPage(pkg)
+	basePath := filepath.Join(dir, filepath.FromSlash(pkg.path))
+	if err := os.MkdirAll(filepath.Dir(basePath), 0755); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(basePath+`.md`, []byte(page.markdown()), 0644); err != nil {
+		return err
+	}
+	html, err := page.html()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(basePath+`.html`, []byte(html), 0644)
+}
+
+func newDocPage(pkg *reportedPackage) docPage {
+	page := docPage{Title: pkg.path, Summary: map[testStatus]int{}}
+	for _, root := range pkg.roots {
+		section := docNode{Title: root.name, Children: newDocNodes(root.spec)}
+		section.count(page.Summary)
+		page.Sections = append(page.Sections, section)
+	}
+	sort.SliceStable(page.Sections, func(i, j int) bool {
+		return page.Sections[i].Title < page.Sections[j].Title
+	})
+	return page
+}
+
+type docPage struct {
+	Title    string
+	Summary  map[testStatus]int
+	Sections []docNode
+}
+
+type docNode struct {
+	Title    string
+	Group    string
+	Tags     []string
+	IsTest   bool
+	Status   testStatus
+	Reason   string
+	Children []docNode
+}
+
+func newDocNodes(spec *Spec) []docNode {
+	var nodes []docNode
+	for _, child := range spec.children {
+		if child.result == nil && child.description == `` {
+			// a context without description is only an implementation detail of the specification
+			nodes = append(nodes, newDocNodes(child)...)
+			continue
+		}
+		nodes = append(nodes, newDocNode(child))
+	}
+	return nodes
+}
+
+func newDocNode(spec *Spec) docNode {
+	node := docNode{
+		Title: spec.description,
+		Tags:  spec.tags,
+	}
+	if spec.group != nil && !strings.Contains(spec.description, spec.group.name) {
+		node.Group = spec.group.name
+	}
+	if spec.result != nil {
+		node.IsTest = true
+		node.Status, node.Reason, _ = spec.result.get()
+		if node.Status == testStatusNotRun {
+			node.Status = testStatusSkipped
+		}
+		if node.Title == `` {
+			node.Title = `(unnamed test)`
+		}
+		return node
+	}
+	node.Children = newDocNodes(spec)
+	return node
+}
+
+func (n docNode) count(summary map[testStatus]int) {
+	if n.IsTest {
+		summary[n.Status]++
+	}
+	for _, child := range n.Children {
+		child.count(summary)
+	}
+}
+
+var docStatusOrder = []testStatus{testStatusPassed, testStatusFailed, testStatusSkipped, testStatusPending}
+
+func (p docPage) summaryLine() string {
+	var parts []string
+	for _, status := range docStatusOrder {
+		parts = append(parts, fmt.Sprintf(`%d %s`, p.Summary[status], status))
+	}
+	return strings.Join(parts, `, `)
+}
+
+//------------------------------------------------------ Markdown ------------------------------------------------------//
+
+func (p docPage) markdown() string {
+	var buf bytes.Buffer
+	_, _ = fmt.Fprintf(&buf, "# %s\n\n%s\n", p.Title, p.summaryLine())
+	for _, section := range p.Sections {
+		_, _ = fmt.Fprintf(&buf, "\n## %s\n\n", section.Title)
+		for _, child := range section.Children {
+			child.markdown(&buf, 0)
+		}
+	}
+	return buf.String()
+}
+
+func (n docNode) markdown(buf *bytes.Buffer, depth int) {
+	_, _ = fmt.Fprintf(buf, "%s- %s", strings.Repeat(`  `, depth), n.Title)
+	if n.Group != `` {
+		_, _ = fmt.Fprintf(buf, " (%s)", n.Group)
+	}
+	for _, tag := range n.Tags {
+		_, _ = fmt.Fprintf(buf, " `%s`", tag)
+	}
+	if n.IsTest {
+		_, _ = fmt.Fprintf(buf, " — **%s**", n.Status)
+		if n.Reason != `` {
+			_, _ = fmt.Fprintf(buf, ": %s", n.Reason)
+		}
+	}
+	buf.WriteString("\n")
+	for _, child := range n.Children {
+		child.markdown(buf, depth+1)
+	}
+}
+
+//-------------------------------------------------------- HTML --------------------------------------------------------//
+
+func (p docPage) html() (string, error) {
+	var buf bytes.Buffer
+	err := docHTMLTemplate.Execute(&buf, struct {
+		docPage
+		SummaryLine string
+	}{docPage: p, SummaryLine: p.summaryLine()})
+	return buf.String(), err
+}
+
+var docHTMLTemplate = template.Must(template.New(`page`).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+li { margin: 0.2em 0; }
+.tag { background: #eee; border-radius: 3px; padding: 0 0.3em; font-size: 0.8em; }
+.group { color: #666; }
+.status { font-weight: bold; }
+.passed .status { color: #2a7d2a; }
+.failed .status { color: #c0392b; }
+.skipped .status { color: #888; }
+.pending .status { color: #d68910; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.SummaryLine}}</p>
+{{range .Sections}}<h2>{{.Title}}</h2>
+<ul>{{range .Children}}{{template "node" .}}{{end}}</ul>
+{{end}}</body>
+</html>
+{{define "node"}}<li{{if .IsTest}} class="{{.Status}}"{{end}}>{{.Title}}{{if .Group}} <span class="group">({{.Group}})</span>{{end}}{{range .Tags}} <span class="tag">{{.}}</span>{{end}}{{if .IsTest}} <span class="status">{{.Status}}</span>{{if .Reason}}: {{.Reason}}{{end}}{{end}}{{if .Children}}<ul>{{range .Children}}{{template "node" .}}{{end}}</ul>{{end}}</li>
+{{end}}`))
@@ -0,0 +1,70 @@
+package testcase
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal"
+)
+
+func TestSpec_documentation(t *testing.T) {
+	dir := t.TempDir()
+	SetEnv(t, EnvKeyDocOutput, dir)
+	UnsetEnv(t, EnvKeyJUnitOutput)
+	SetEnv(t, EnvKeyOrdering, string(OrderingAsDefined))
+	internal.SetupCacheFlush(t)
+
+	stub := &internal.StubTB{StubName: `TestSubject`}
+	s := NewSpec(stubRunner{StubTB: stub})
+	s.Describe(`#Add`, func(s *Spec) {
+		s.Tag(`unit`)
+		s.When(`the value is positive`, func(s *Spec) {
+			s.Then(`it increases the total`, func(t *T) {})
+			s.Then(`it reports the change`, func(t *T) { t.Fail() })
+		})
+		s.Context(``, func(s *Spec) {
+			s.Then(`it is skipped`, func(t *T) { t.Skip() })
+		})
+		s.Todo(`it handles overflow`)
+	})
+	stub.Finish()
+
+	base := filepath.Join(dir, `github.com`, `adamluzsi`, `testcase`)
+	md, err := ioutil.ReadFile(base + `.md`)
+	assert.Must(t).Nil(err)
+	assert.Must(t).Equal(""+
+		"# github.com/adamluzsi/testcase\n"+
+		"\n"+
+		"1 passed, 1 failed, 1 skipped, 1 pending\n"+
+		"\n"+
+		"## TestSubject\n"+
+		"\n"+
+		"- describe #Add `unit`\n"+
+		"  - when the value is positive\n"+
+		"    - then it increases the total — **passed**\n"+
+		"    - then it reports the change — **failed**\n"+
+		"  - then it is skipped — **skipped**\n"+
+		"  - then it handles overflow — **pending**: TODO\n",
+		string(md))
+
+	html, err := ioutil.ReadFile(base + `.html`)
+	assert.Must(t).Nil(err)
+	assert.Must(t).Contain(string(html), `<h1>github.com/adamluzsi/testcase</h1>`)
+	assert.Must(t).Contain(string(html), `<li class="failed">then it reports the change <span class="status">failed</span>`)
+	assert.Must(t).Contain(string(html), `<span class="tag">unit</span>`)
+}
+
+func TestSpec_documentation_disabledByDefault(t *testing.T) {
+	UnsetEnv(t, EnvKeyDocOutput)
+	UnsetEnv(t, EnvKeyJUnitOutput)
+	s := NewSpec(&internal.StubTB{})
+	assert.Must(t).Nil(s.reports)
+}
+
+func TestPackagePathOfFunc(t *testing.T) {
+	assert.Must(t).Equal(`github.com/foo/bar`, packagePathOfFunc(`github.com/foo/bar_test.TestBaz.func1`))
+	assert.Must(t).Equal(`github.com/foo/bar`, packagePathOfFunc(`github.com/foo/bar.(*T).Method`))
+	assert.Must(t).Equal(`main`, packagePathOfFunc(`main.main`))
+}
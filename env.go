@@ -21,8 +21,113 @@ const EnvKeySeed = `TESTCASE_SEED`
 // Mods:
 // - defined: execute testCase in the order which they are being defined
 // - random: pseudo random based ordering between tests.
+// - reversed: execute testCase in the reverse order of their definition.
+// - slowest-first: execute first the testCase that were the slowest in the previous test run.
+// - failed-first: execute first the testCase that failed in the previous test run.
 const EnvKeyOrdering = `TESTCASE_ORDERING`
 
+// EnvKeyOrderingHistory is the environment variable key that will be checked for the path of the file,
+// where the test results are recorded for the slowest-first and failed-first ordering mods.
+// By default, the history is recorded into a file in the user's cache directory (see os.UserCacheDir),
+// which is unique to the package directory.
+const EnvKeyOrderingHistory = `TESTCASE_ORDERING_HISTORY`
+
+// EnvKeyFocusForbidden is the environment variable key that will be checked to forbid the use of Focus.
+// When it is set to a true value, the focused specs are ignored, and the specification is marked as failed.
+// It is meant to be set in the CI/CD pipelines, so focused specs can't be merged by accident.
+const EnvKeyFocusForbidden = `TESTCASE_FOCUS_FORBIDDEN`
+
+// EnvKeyDocOutput is the environment variable key that will be checked for a directory path,
+// where the living documentation of the specifications will be written.
+// For each package, a Markdown and an HTML file is created,
+// which describes the behaviours from the specification with their test results.
+//
+// example usage:
+// 	TESTCASE_DOC_OUTPUT=./docs/behaviour go test ./...
+const EnvKeyDocOutput = `TESTCASE_DOC_OUTPUT`
+
+// EnvKeyJUnitOutput is the environment variable key that will be checked for the path of the JUnit XML report.
+// When the path points to an xml file, the report is written into that file,
+// else the path is used as a directory, and the report of each package is written into a separate file.
+// The testing groups (e.g.: Describe) are represented as test suites in the report.
+//
+// example usage:
+// 	TESTCASE_JUNIT_OUTPUT=./reports go test ./...
+const EnvKeyJUnitOutput = `TESTCASE_JUNIT_OUTPUT`
+
+// EnvKeyFlakyReport is the environment variable key that will be checked for the path of the flaky ledger.
+// Each execution of a test marked with Flaky is appended to the ledger as a JSON line,
+// with the number of attempts it needed, the failure messages of the discarded attempts,
+// and the source location of the test.
+// This allows finding the tests that are really flaky, without searching for the Flaky flags.
+//
+// example usage:
+// 	TESTCASE_FLAKY_REPORT=./flaky.jsonl go test ./...
+const EnvKeyFlakyReport = `TESTCASE_FLAKY_REPORT`
+
+// EnvKeyTagDryRun is the environment variable key that will be checked to list the tests,
+// which are selected by the TESTCASE_TAG_INCLUDE and TESTCASE_TAG_EXCLUDE tag settings, without executing them.
+//
+// example usage:
+// 	TESTCASE_TAG_INCLUDE='integration && !slow' TESTCASE_TAG_DRY_RUN=true go test -v ./...
+const EnvKeyTagDryRun = `TESTCASE_TAG_DRY_RUN`
+
+// EnvKeyShard is the environment variable key that will be checked for the shard of the current test run,
+// in the form of i/n, where n is the total number of the shards, and i is the current one, starting from 1.
+// Each test is assigned to exactly one shard based on its id, which is made of the Test function's name and the description path,
+// and the tests of the other shards are reported as skipped.
+// This allows splitting a testing suite between multiple CI machines.
+//
+// example usage:
+// 	TESTCASE_SHARD=1/3 go test ./...
+// 	TESTCASE_SHARD=2/3 go test ./...
+// 	TESTCASE_SHARD=3/3 go test ./...
+const EnvKeyShard = `TESTCASE_SHARD`
+
+// EnvKeyShardTimings is the environment variable key that will be checked for the path of a timing file,
+// which is used to balance the shards by the recorded test durations.
+// Sharding doesn't record durations on its own, the timing file is an ordering history file,
+// which is recorded by a test run with the slowest-first or failed-first ordering (see EnvKeyOrderingHistory).
+// The recorded ids contain the name of the Test function and the description path of the test,
+// thus the history should be recorded per package, and every shard must use the same timing file,
+// else the shards would assign the tests differently.
+// Tests without a recorded duration are assigned to a shard based on their id.
+//
+// example usage:
+// 	TESTCASE_ORDERING=slowest-first TESTCASE_ORDERING_HISTORY=$PWD/timings.json go test .
+// 	TESTCASE_SHARD=1/2 TESTCASE_SHARD_TIMINGS=$PWD/timings.json go test .
+// 	TESTCASE_SHARD=2/2 TESTCASE_SHARD_TIMINGS=$PWD/timings.json go test .
+const EnvKeyShardTimings = `TESTCASE_SHARD_TIMINGS`
+
+// EnvKeyBenchBaseline is the environment variable key that will be checked for the path of the benchmark baseline file.
+// The baseline holds the ns/op, B/op and allocs/op samples of the benchmarks,
+// keyed by the name of the Benchmark function and their description path.
+// When the baseline is present, the benchmarks are compared against it,
+// and a benchmark that became slower than the tolerance (see BenchmarkTolerance) fails with a regression report.
+//
+// example usage:
+// 	TESTCASE_BENCH_BASELINE=./bench.json TESTCASE_BENCH_UPDATE=1 go test -bench . -run ^$ ./...
+// 	TESTCASE_BENCH_BASELINE=./bench.json go test -bench . -run ^$ ./...
+const EnvKeyBenchBaseline = `TESTCASE_BENCH_BASELINE`
+
+// EnvKeyBenchUpdate is the environment variable key that will be checked to record
+// the results of the benchmarks into the baseline file, instead of comparing them against it.
+const EnvKeyBenchUpdate = `TESTCASE_BENCH_UPDATE`
+
+// EnvKeyGoroutineLeaks is the environment variable key that will be checked to enable
+// the goroutine leak detection for every test (see DetectGoroutineLeaks).
+//
+// example usage:
+// 	TESTCASE_GOROUTINE_LEAKS=true go test ./...
+const EnvKeyGoroutineLeaks = `TESTCASE_GOROUTINE_LEAKS`
+
+// EnvKeyFailureReportValues is the environment variable key that will be checked to include
+// the values of the variables in the failure report of every test (see FailureReportValues).
+//
+// example usage:
+// 	TESTCASE_FAILURE_REPORT_VALUES=true go test ./...
+const EnvKeyFailureReportValues = `TESTCASE_FAILURE_REPORT_VALUES`
+
 //------------------------------------------------------- Seed -------------------------------------------------------//
 
 func getSeed(tb testing.TB) (_seed int64) {
@@ -0,0 +1,45 @@
+package testcase_test
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+)
+
+func ExampleLet() {
+	var t *testing.T
+	s := testcase.NewSpec(t)
+
+	myType := testcase.Let(s, `my type`, func(t *testcase.T) *MyType {
+		return &MyType{}
+	})
+
+	s.Test(`some testCase`, func(t *testcase.T) {
+		myType.Get(t).MyFunc() // no type casting is needed
+	})
+}
+
+func ExampleLetValue() {
+	var t *testing.T
+	s := testcase.NewSpec(t)
+
+	value := testcase.LetValue(s, `some value`, 42)
+
+	s.Test(`some testCase`, func(t *testcase.T) {
+		_ = value.Get(t) + 1 // -> 43
+	})
+}
+
+func ExampleVarOf() {
+	var t *testing.T
+	s := testcase.NewSpec(t)
+
+	// during a migration, the Var and the VarOf can access the same variable through its name
+	untyped := s.Let(`value`, func(t *testcase.T) interface{} { return 42 })
+	typed := testcase.VarOf[int]{Name: untyped.Name}
+
+	s.Test(`some testCase`, func(t *testcase.T) {
+		_ = typed.Get(t)         // -> 42
+		_ = untyped.Get(t).(int) // -> 42
+	})
+}
@@ -88,3 +88,47 @@ func ExampleRetry_byCustomRetryStrategy() {
 		}
 	})
 }
+
+func ExampleBackoff() {
+	r := testcase.Retry{Strategy: testcase.Backoff{
+		Interval:    10 * time.Millisecond,
+		MaxInterval: time.Second,
+		Jitter:      0.1,
+		Timeout:     10 * time.Second,
+	}}
+
+	var t *testing.T
+	r.Assert(t, func(tb testing.TB) {
+		if rand.Intn(1) == 0 {
+			tb.Fatal(`boom`)
+		}
+	})
+}
+
+func ExampleEither() {
+	// retry 5 times at most, but not longer than 10 seconds
+	r := testcase.Retry{Strategy: testcase.Either(
+		testcase.RetryCount(5),
+		testcase.Waiter{WaitDuration: time.Millisecond, WaitTimeout: 10 * time.Second},
+	)}
+
+	var t *testing.T
+	r.Assert(t, func(tb testing.TB) {
+		if rand.Intn(1) == 0 {
+			tb.Fatal(`boom`)
+		}
+	})
+}
+
+func ExampleAttemptRetryStrategy() {
+	r := testcase.Retry{Strategy: testcase.AttemptRetryStrategy(func(attempt int) (time.Duration, bool) {
+		return time.Duration(attempt) * 100 * time.Millisecond, attempt < 5
+	})}
+
+	var t *testing.T
+	r.Assert(t, func(tb testing.TB) {
+		if testcase.RetryAttempt(tb) < 3 {
+			tb.Fatal(`boom`)
+		}
+	})
+}
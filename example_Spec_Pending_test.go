@@ -0,0 +1,39 @@
+package testcase_test
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+)
+
+func ExampleSpec_Pending() {
+	var t *testing.T
+	s := testcase.NewSpec(t)
+
+	s.Context(`sub spec`, func(s *testcase.Spec) {
+		s.Pending(`waiting for the new storage implementation`)
+
+		s.Test(`will be reported as pending`, func(t *testcase.T) {})
+
+		s.Context(`pending as well just like the tests of the parent`, func(s *testcase.Spec) {
+			s.Test(`will be reported as pending`, func(t *testcase.T) {})
+		})
+	})
+
+	s.Test(`this will still run since it is not part of the scope where Spec#Pending was called`, func(t *testcase.T) {})
+}
+
+func ExampleSpec_Todo() {
+	var t *testing.T
+	s := testcase.NewSpec(t)
+
+	s.Describe(`#Add`, func(s *testcase.Spec) {
+		s.When(`the value is negative`, func(s *testcase.Spec) {
+			s.Todo(`it will decrease the total`)
+		})
+
+		s.When(`the value is positive`, func(s *testcase.Spec) {
+			s.Todo(`it will increase the total`)
+		})
+	})
+}
@@ -0,0 +1,23 @@
+package testcase_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+)
+
+func ExampleSpec_Property() {
+	var t *testing.T
+	s := testcase.NewSpec(t)
+
+	s.Describe(`strings.Repeat`, func(s *testcase.Spec) {
+		s.Property(`the length is the multiple of the count`, func(t *testcase.T, gen *testcase.Gen) {
+			var (
+				str   = gen.String()
+				count = gen.IntBetween(0, 10)
+			)
+			t.Must.Equal(len(str)*count, len(strings.Repeat(str, count)))
+		}, testcase.PropertyRuns(1000))
+	})
+}
@@ -0,0 +1,39 @@
+package testcase_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+)
+
+func ExampleSpec_Table() {
+	var t *testing.T
+	s := testcase.NewSpec(t)
+
+	var (
+		input    = testcase.Var{Name: `input`}
+		expected = testcase.Var{Name: `expected`}
+		subject  = func(t *testcase.T) string {
+			return strings.ToUpper(input.Get(t).(string))
+		}
+	)
+
+	type Row struct {
+		Name     string
+		Input    string `testcase:"input"`
+		Expected string `testcase:"expected"`
+	}
+
+	s.Describe(`#ToUpper`, func(s *testcase.Spec) {
+		s.Table(`cases`, []Row{
+			{Name: `lower case`, Input: `foo`, Expected: `FOO`},
+			{Name: `mixed case`, Input: `fOo`, Expected: `FOO`},
+			{Name: `empty string`, Input: ``, Expected: ``},
+		}, func(s *testcase.Spec, row interface{}) {
+			s.Then(`it returns the upper case form`, func(t *testcase.T) {
+				t.Must.Equal(expected.Get(t), subject(t))
+			})
+		})
+	})
+}
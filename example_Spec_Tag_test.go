@@ -14,6 +14,7 @@ func ExampleSpec_Tag() {
 		// by tagging the spec spec, we can filter tests orderingOutput later in our CI/CD pipeline.
 		// A comma separated list can be set with TESTCASE_TAG_INCLUDE env variable to filter down to tests with certain tags.
 		// And/Or a comma separated list can be provided with TESTCASE_TAG_EXCLUDE to exclude tests tagged with certain tags.
+		// Both accept tag expressions as well, like `(E2E || integration) && !slow`.
 		s.Tag(`E2E`)
 
 		s.Test(`some E2E testCase`, func(t *testcase.T) {
@@ -26,4 +27,5 @@ func ExampleSpec_Tag() {
 // 	TESTCASE_TAG_INCLUDE='E2E' go testCase ./...
 // 	TESTCASE_TAG_EXCLUDE='E2E' go testCase ./...
 // 	TESTCASE_TAG_INCLUDE='E2E' TESTCASE_TAG_EXCLUDE='list,of,excluded,tags' go testCase ./...
+// 	TESTCASE_TAG_INCLUDE='E2E && !slow' TESTCASE_TAG_DRY_RUN=true go testCase -v ./...
 //
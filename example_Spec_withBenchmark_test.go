@@ -24,3 +24,39 @@ func ExampleSpec_withBenchmark() {
 		})
 	})
 }
+
+func ExampleSpec_withRichBenchmark() {
+	var b *testing.B
+	s := testcase.NewSpec(b, testcase.ReportAllocs())
+
+	input := testcase.Let(s, `input`, func(t *testcase.T) string {
+		return t.Random.String()
+	})
+
+	s.Test(`only the measured region is timed`, func(t *testcase.T) {
+		in := input.Get(t) // the Let initialization is excluded from the timing
+		var out bool
+		t.Measure(func() {
+			out = (&MyType{}).IsLower(in)
+		})
+		_ = out
+		t.ReportMetric(float64(len(in)), `chars/op`)
+	})
+
+	s.Test(`executed with b.RunParallel`, func(t *testcase.T) {
+		(&MyType{}).IsLower(input.Get(t)) // input is initialized per goroutine
+	}, testcase.ParallelBenchmark())
+}
+
+func ExampleSpec_withBenchmarkBaseline() {
+	// record the baseline:
+	//	TESTCASE_BENCH_BASELINE=./bench.json TESTCASE_BENCH_UPDATE=1 go test -bench . -run ^$
+	// compare against the baseline:
+	//	TESTCASE_BENCH_BASELINE=./bench.json go test -bench . -run ^$
+	var b *testing.B
+	s := testcase.NewSpec(b, testcase.BenchmarkTolerance(20))
+
+	s.Test(`fails when it becomes 20% slower than the baseline`, func(t *testcase.T) {
+		(&MyType{}).IsLower(`Hello, World!`)
+	})
+}
@@ -0,0 +1,48 @@
+package testcase_test
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+)
+
+func ExampleStateMachine() {
+	var t *testing.T
+	s := testcase.NewSpec(t)
+
+	var (
+		cache = s.Let(`cache`, func(t *testcase.T) interface{} {
+			return make(map[string]int) // the system under test
+		})
+		model = s.Let(`model`, func(t *testcase.T) interface{} {
+			return make(map[string]int) // the reference model
+		})
+	)
+
+	s.Test(`the cache behaves like a map`, testcase.StateMachine{
+		Commands: []testcase.StateMachineCommand{
+			{
+				Name: `Set`,
+				Run: func(t *testcase.T, gen *testcase.Gen) {
+					key, value := gen.StringN(1), gen.Int()
+					cache.Get(t).(map[string]int)[key] = value
+					model.Get(t).(map[string]int)[key] = value
+				},
+			},
+			{
+				Name: `Delete`,
+				Precondition: func(t *testcase.T) bool {
+					return 0 < len(model.Get(t).(map[string]int))
+				},
+				Run: func(t *testcase.T, gen *testcase.Gen) {
+					key := gen.StringN(1)
+					delete(cache.Get(t).(map[string]int), key)
+					delete(model.Get(t).(map[string]int), key)
+				},
+			},
+		},
+		Invariant: func(t *testcase.T) {
+			t.Must.Equal(model.Get(t), cache.Get(t))
+		},
+	}.Check)
+}
@@ -0,0 +1,27 @@
+package testcase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase"
+	"github.com/adamluzsi/testcase/assert"
+)
+
+func ExampleT_clock() {
+	var tb testing.TB
+	s := testcase.NewSpec(tb)
+	s.Test(``, func(t *testcase.T) {
+		t.Clock.Freeze()
+		expiresAt := t.Clock.Now().Add(time.Hour)
+		timer := t.Clock.NewTimer(time.Hour)
+
+		t.Clock.Advance(time.Hour)
+		<-timer.C()
+		t.Must.True(!t.Clock.Now().Before(expiresAt))
+
+		t.Eventually(func(it assert.It) {
+			// the Waiter advances the fake time between the attempts
+		}, testcase.Waiter{WaitDuration: time.Minute, WaitTimeout: time.Hour, Clock: t.Clock})
+	})
+}
@@ -234,3 +234,29 @@ func ExampleVar_before() {
 		// -> 42
 	})
 }
+
+func ExampleVar_LetSuper() {
+	var tb testing.TB
+	s := testcase.NewSpec(tb)
+
+	type User struct {
+		Name  string
+		Admin bool
+	}
+
+	user := testcase.Let(s, `user`, func(t *testcase.T) User {
+		return User{Name: t.Random.String()}
+	})
+
+	s.When(`user is an admin`, func(s *testcase.Spec) {
+		user.LetSuper(s, func(t *testcase.T, super func() User) User {
+			u := super() // the user from the outer scope
+			u.Admin = true
+			return u
+		})
+
+		s.Then(`...`, func(t *testcase.T) {
+			_ = user.Get(t).Admin // true
+		})
+	})
+}
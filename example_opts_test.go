@@ -1,6 +1,8 @@
 package testcase_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -52,3 +54,56 @@ func ExampleFlaky_retryNTimes() {
 		// and then allocate time outside of death-march times to learn to avoid retry tests in the future.
 	}, testcase.Flaky(42))
 }
+
+func ExampleTimeout() {
+	var tb testing.TB
+	s := testcase.NewSpec(tb)
+
+	s.Test(`testCase that might hang`, func(t *testcase.T) {
+		// If this testCase runs longer than a second, it will fail with a goroutine dump,
+		// instead of blocking the whole test run until the global go test -timeout is reached.
+	}, testcase.Timeout(time.Second))
+}
+
+func ExampleOrderWith() {
+	var tb testing.TB
+	historyPath := filepath.Join(os.TempDir(), `my-project-ordering-history.json`)
+	s := testcase.NewSpec(tb, testcase.OrderWith(testcase.FailedFirstOrderer(historyPath)))
+
+	s.Test(`testCase that failed in the previous test run will run first`, func(t *testcase.T) {})
+}
+
+func ExampleFocus() {
+	var tb testing.TB
+	s := testcase.NewSpec(tb)
+
+	s.Test(`will be skipped`, func(t *testcase.T) {})
+
+	s.Test(`will run`, func(t *testcase.T) {
+		// only the focused tests run, until the Focus is removed
+	}, testcase.Focus())
+}
+
+func ExamplePropertyRuns() {
+	var t *testing.T
+	s := testcase.NewSpec(t)
+
+	s.Property(`executed with 500 different input`, func(t *testcase.T, gen *testcase.Gen) {
+		_ = gen.Int()
+	}, testcase.PropertyRuns(500))
+}
+
+func ExampleDetectGoroutineLeaks() {
+	var tb testing.TB
+	s := testcase.NewSpec(tb, testcase.DetectGoroutineLeaks())
+
+	s.Test(`goroutines started by the test must finish by the end of the teardown`, func(t *testcase.T) {
+		done := make(chan struct{})
+		t.Defer(func() { close(done) })
+		go func() { <-done }()
+	})
+
+	s.Context(`with a known background worker`, func(s *testcase.Spec) {
+		s.Test(``, func(t *testcase.T) {})
+	}, testcase.IgnoreGoroutines(`database/sql\.\(\*DB\)\.connectionOpener`))
+}
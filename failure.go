@@ -0,0 +1,118 @@
+package testcase
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/adamluzsi/testcase/internal"
+)
+
+// ranHook is a hook that was executed during the set-up of a test.
+type ranHook struct {
+	hook  hook
+	scope string
+}
+
+// logFailureReport logs where the contexts and the hooks of the failed test were declared,
+// along with the variables that were initialized during the test.
+// The values of the variables are only logged when it is enabled with FailureReportValues,
+// since they may hold secrets.
+func (spec *Spec) logFailureReport(t *T) {
+	spec.testingTB.Helper()
+	t.TB.Helper()
+	if !t.TB.Failed() {
+		return
+	}
+	log(t, spec.failureReport(t))
+}
+
+func (spec *Spec) isReportingValues() bool {
+	spec.testingTB.Helper()
+	if isFailureReportValuesEnabled() {
+		return true
+	}
+	for _, context := range spec.list() {
+		if context.reportValues {
+			return true
+		}
+	}
+	return false
+}
+
+func isFailureReportValuesEnabled() bool {
+	raw, ok := os.LookupEnv(EnvKeyFailureReportValues)
+	if !ok || raw == `` {
+		return false
+	}
+	enabled, err := strconv.ParseBool(raw)
+	return err != nil || enabled
+}
+
+func (spec *Spec) failureReport(t *T) string {
+	var msg strings.Builder
+	msg.WriteString("failure report:\n\nspec:\n")
+	var depth int
+	for _, c := range spec.list() {
+		desc := c.description
+		if desc == `` {
+			if c.parent != nil {
+				continue
+			}
+			desc = `NewSpec`
+		}
+		depth++
+		_, _ = fmt.Fprintf(&msg, "%s%s (%s)\n", strings.Repeat(`  `, depth), desc, c.location)
+	}
+	if 0 < len(t.hooks) {
+		msg.WriteString("\nhooks:\n")
+		for _, h := range t.hooks {
+			_, _ = fmt.Fprintf(&msg, "  %s of %s (%s)\n", h.hook.kind, h.scope, h.hook.location)
+		}
+	}
+	if values := t.vars.values(); 0 < len(values) {
+		msg.WriteString("\nvariables:\n")
+		withValues := spec.isReportingValues()
+		for _, v := range values {
+			if !withValues {
+				_, _ = fmt.Fprintf(&msg, "  %s (%s)\n", v.name, v.origin)
+				continue
+			}
+			_, _ = fmt.Fprintf(&msg, "  %s (%s):\n", v.name, v.origin)
+			for _, line := range strings.Split(internal.Pretty(v.value), "\n") {
+				_, _ = fmt.Fprintf(&msg, "    %s\n", line)
+			}
+		}
+	}
+	return msg.String()
+}
+
+type variableValue struct {
+	name   string
+	origin string
+	value  interface{}
+}
+
+// values returns the initialized variables in alphabetical order.
+func (v *variables) values() []variableValue {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	initialized := make(map[string]struct{})
+	for _, name := range v.inits {
+		initialized[name] = struct{}{}
+	}
+	var values []variableValue
+	for name, value := range v.cache {
+		origin := `set during the test`
+		if scope, ok := v.scopes[name]; ok {
+			origin = fmt.Sprintf(`Let of %s at %s`, scope, v.locations[name])
+		} else if _, ok := initialized[name]; ok {
+			origin = `Var.Init`
+		}
+		values = append(values, variableValue{name: name, origin: origin, value: value})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].name < values[j].name })
+	return values
+}
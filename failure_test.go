@@ -0,0 +1,119 @@
+package testcase
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal"
+)
+
+func TestSpec_failureReport(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := NewSpec(stub, FailureReportValues())
+	_, file, line, _ := runtime.Caller(0)
+	loc := func(offset int) string { return fmt.Sprintf(`%s:%d`, fileBase(file), line+offset) }
+
+	s.Describe(`subject`, func(s *Spec) {
+		s.Before(func(t *T) {})
+		Let(s, `value`, func(t *T) []string { return []string{`foo`} })
+
+		s.When(`something`, func(s *Spec) {
+			s.Around(func(t *T) func() { return func() {} })
+
+			s.Then(`it fails`, func(t *T) {
+				_ = t.I(`value`)
+				t.Set(`other`, 42)
+				t.Fail()
+			})
+		})
+	})
+	stub.Finish()
+
+	assert.Must(t).True(stub.IsFailed)
+	logs := strings.Join(stub.Logs, "\n")
+	assert.Must(t).Contain(logs, `failure report:`)
+	assert.Must(t).Contain(logs, fmt.Sprintf(`describe subject (%s)`, loc(3)))
+	assert.Must(t).Contain(logs, fmt.Sprintf(`when something (%s)`, loc(7)))
+	assert.Must(t).Contain(logs, fmt.Sprintf(`then it fails (%s)`, loc(10)))
+	assert.Must(t).Contain(logs, fmt.Sprintf(`Before of "describe subject" (%s)`, loc(4)))
+	assert.Must(t).Contain(logs, fmt.Sprintf(`Around of "when something" (%s)`, loc(8)))
+	assert.Must(t).Contain(logs, fmt.Sprintf(`value (Let of "describe subject" at %s):`, loc(5)))
+	assert.Must(t).Contain(logs, `[]string{`)
+	assert.Must(t).Contain(logs, `"foo",`)
+	assert.Must(t).Contain(logs, `other (set during the test):`)
+}
+
+func TestSpec_failureReport_passingTest(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := NewSpec(stub)
+	s.Test(`it passes`, func(t *T) {})
+	stub.Finish()
+
+	assert.Must(t).True(!stub.IsFailed)
+	assert.Must(t).True(!strings.Contains(strings.Join(stub.Logs, "\n"), `failure report:`))
+}
+
+func TestSpec_failureReport_valuesNotEnabled(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := NewSpec(stub)
+	_, file, line, _ := runtime.Caller(0)
+	s.Before(func(t *T) {})
+	s.LetValue(`secret`, `s3cr3t`)
+	s.Test(`it fails`, func(t *T) {
+		_ = t.I(`secret`)
+		t.Fail()
+	})
+	stub.Finish()
+
+	assert.Must(t).True(stub.IsFailed)
+	logs := strings.Join(stub.Logs, "\n")
+	assert.Must(t).Contain(logs, `failure report:`)
+	assert.Must(t).Contain(logs, fmt.Sprintf(`Before of the root spec (%s:%d)`, fileBase(file), line+1))
+	assert.Must(t).Contain(logs, fmt.Sprintf(`secret (Let of the root spec at %s:%d)`, fileBase(file), line+2))
+	assert.Must(t).True(!strings.Contains(logs, `s3cr3t`))
+}
+
+func TestSpec_failureReport_valuesEnabledWithEnv(t *testing.T) {
+	SetEnv(t, EnvKeyFailureReportValues, `true`)
+	stub := &internal.StubTB{}
+	s := NewSpec(stub)
+	s.LetValue(`secret`, `s3cr3t`)
+	s.Test(`it fails`, func(t *T) {
+		_ = t.I(`secret`)
+		t.Fail()
+	})
+	stub.Finish()
+
+	assert.Must(t).True(stub.IsFailed)
+	assert.Must(t).Contain(strings.Join(stub.Logs, "\n"), `s3cr3t`)
+}
+
+func TestT_setUp_resetsTheRanHooks(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := NewSpec(stub)
+	s.Around(func(t *T) func() { return func() {} })
+	tc := newT(stub, s)
+	tc.setUp()()
+	tc.setUp()()
+	assert.Must(t).Equal(1, len(tc.hooks))
+}
+
+func TestSpec_panicInHook(t *testing.T) {
+	stub := &internal.StubTB{}
+	s := NewSpec(stub)
+	_, file, line, _ := runtime.Caller(0)
+	s.Before(func(t *T) { panic(`boom`) })
+	s.Test(`test`, func(t *T) {})
+	stub.Finish()
+
+	assert.Must(t).True(stub.IsFailed)
+	assert.Must(t).Contain(strings.Join(stub.Logs, "\n"),
+		fmt.Sprintf(`boom (Before hook declared at %s:%d)`, fileBase(file), line+1))
+}
+
+func fileBase(path string) string {
+	return path[strings.LastIndex(path, `/`)+1:]
+}
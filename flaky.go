@@ -0,0 +1,141 @@
+package testcase
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testOrigin tells where a test was defined.
+type testOrigin struct {
+	pkg      string
+	location string
+}
+
+func (spec *Spec) registerOrigin() {
+	spec.testingTB.Helper()
+	if _, ok := spec.lookupRetryFlaky(); !ok {
+		return
+	}
+	spec.origin = &testOrigin{
+		pkg:      spec.list()[0].pkgPath,
+		location: spec.callerLocationName(1),
+	}
+}
+
+// runFlaky executes a test marked with Flaky,
+// and records the attempts it needed in the flaky ledger.
+func (spec *Spec) runFlaky(tb testing.TB, retry Retry, test func(tb testing.TB, attempt *testResult)) {
+	spec.testingTB.Helper()
+	tb.Helper()
+	defer func() { spec.recordFlaky(spec.result.getAttempts(), !tb.Failed()) }()
+	retry.Assert(tb, func(tb testing.TB) {
+		tb.Helper()
+		test(tb, spec.result.newAttempt())
+	})
+}
+
+func (spec *Spec) recordFlaky(attempts []*testResult, passed bool) {
+	spec.testingTB.Helper()
+	if len(attempts) == 0 {
+		return
+	}
+	entry := flakyLedgerEntry{
+		Time:     time.Now().UTC(),
+		Test:     spec.descriptionPathLine(),
+		Attempts: len(attempts),
+		Passed:   passed,
+		Failures: [][]string{},
+	}
+	if spec.origin != nil {
+		entry.Package = spec.origin.pkg
+		entry.Location = spec.origin.location
+	}
+	for _, attempt := range attempts[:len(attempts)-1] {
+		entry.Failures = append(entry.Failures, attempt.getFailures())
+	}
+	if passed && 1 < len(attempts) {
+		spec.list()[0].flakyTests.add(entry)
+	}
+	if path, ok := getFlakyReportPath(); ok {
+		if err := appendFlakyLedger(path, entry); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "testcase: unable to write the flaky report to %s: %s\n", path, err.Error())
+		}
+	}
+}
+
+func (spec *Spec) printFlakySummary() {
+	spec.testingTB.Helper()
+	if spec.parent != nil {
+		return
+	}
+	entries := spec.flakyTests.flush()
+	if len(entries) == 0 {
+		return
+	}
+	lines := []interface{}{fmt.Sprintf("%d flaky test(s) passed only after retries:\n", len(entries))}
+	for _, e := range entries {
+		lines = append(lines, fmt.Sprintf("  %s (%s): %d attempts\n", e.Test, e.Location, e.Attempts))
+	}
+	log(spec.testingTB, lines...)
+}
+
+// flakyLedgerEntry is a line of the flaky ledger, which is a JSON Lines file,
+// so the test runs of multiple packages can append to the same file.
+type flakyLedgerEntry struct {
+	Time     time.Time `json:"time"`
+	Package  string    `json:"package"`
+	Test     string    `json:"test"`
+	Location string    `json:"location"`
+	Attempts int       `json:"attempts"`
+	Passed   bool      `json:"passed"`
+	// Failures holds the failure messages of the discarded attempts.
+	Failures [][]string `json:"failures"`
+}
+
+func getFlakyReportPath() (string, bool) {
+	path, ok := os.LookupEnv(EnvKeyFlakyReport)
+	return path, ok && path != ``
+}
+
+var flakyLedgerMutex sync.Mutex
+
+func appendFlakyLedger(path string, entry flakyLedgerEntry) error {
+	bs, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	flakyLedgerMutex.Lock()
+	defer flakyLedgerMutex.Unlock()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(bs, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type flakyTests struct {
+	mutex   sync.Mutex
+	entries []flakyLedgerEntry
+}
+
+func (fts *flakyTests) add(entry flakyLedgerEntry) {
+	fts.mutex.Lock()
+	defer fts.mutex.Unlock()
+	fts.entries = append(fts.entries, entry)
+}
+
+func (fts *flakyTests) flush() []flakyLedgerEntry {
+	fts.mutex.Lock()
+	defer fts.mutex.Unlock()
+	entries := fts.entries
+	fts.entries = nil
+	return entries
+}
@@ -0,0 +1,70 @@
+package testcase
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal"
+)
+
+func TestSpec_flakyReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), `flaky.jsonl`)
+	SetEnv(t, EnvKeyFlakyReport, path)
+	SetEnv(t, EnvKeyOrdering, string(OrderingAsDefined))
+	internal.SetupCacheFlush(t)
+
+	stub := &internal.StubTB{}
+	s := NewSpec(stubRunner{StubTB: stub})
+	s.Describe(`#Fetch`, func(s *Spec) {
+		var attempts int
+		s.Test(`passes after retries`, func(t *T) {
+			attempts++
+			if attempts < 3 {
+				t.Errorf(`attempt %d failed`, attempts)
+			}
+		}, Flaky(5))
+		s.Test(`passes at first`, func(t *T) {}, Flaky(5))
+		s.Test(`always fails`, func(t *T) { t.Fatal(`boom`) }, Flaky(1))
+		s.Test(`not flaky`, func(t *T) {})
+	})
+	stub.Finish()
+
+	f, err := os.Open(path)
+	assert.Must(t).Nil(err)
+	defer f.Close()
+	var entries []flakyLedgerEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry flakyLedgerEntry
+		assert.Must(t).Nil(json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	assert.Must(t).Equal(3, len(entries))
+
+	retried := entries[0]
+	assert.Must(t).Equal(`describe #Fetch passes after retries`, retried.Test)
+	assert.Must(t).Equal(`github.com/adamluzsi/testcase`, retried.Package)
+	assert.Must(t).Contain(retried.Location, `flaky_test.go:`)
+	assert.Must(t).Equal(3, retried.Attempts)
+	assert.Must(t).True(retried.Passed)
+	assert.Must(t).Equal([][]string{{`attempt 1 failed`}, {`attempt 2 failed`}}, retried.Failures)
+
+	first := entries[1]
+	assert.Must(t).Equal(1, first.Attempts)
+	assert.Must(t).True(first.Passed)
+	assert.Must(t).Equal([][]string{}, first.Failures)
+
+	failed := entries[2]
+	assert.Must(t).Equal(2, failed.Attempts)
+	assert.Must(t).False(failed.Passed)
+	assert.Must(t).Equal([][]string{{`boom`}}, failed.Failures)
+
+	logs := strings.Join(stub.Logs, "\n")
+	assert.Must(t).Contain(logs, "1 flaky test(s) passed only after retries:\n")
+	assert.Must(t).Contain(logs, "  describe #Fetch passes after retries ("+retried.Location+"): 3 attempts\n")
+}
@@ -0,0 +1,92 @@
+package testcase
+
+import (
+	"os"
+	"strconv"
+)
+
+// Focus will mark the current Spec as focused,
+// so only the focused tests will run from the specification tree,
+// and every other test will be reported as skipped.
+// This is meant to be used during development, to quickly iterate on a given part of the specification.
+// Keep in mind, that the tests of a testing group (e.g.: Describe) run when the group's block is finished,
+// thus using Focus after a testing group already ran fails the specification,
+// since the tests of that group could no longer be skipped.
+// Declare the focused spec before the testing groups, or focus the testing group itself.
+//
+// To avoid that a focused specification is merged by accident,
+// set the TESTCASE_FOCUS_FORBIDDEN environment variable in your CI/CD pipeline.
+// When focus is forbidden, every test runs, and the specification fails if it has a focused spec.
+//
+// If you wish to focus only a certain test or context, use the Focus SpecOption instead.
+func (spec *Spec) Focus() {
+	spec.testingTB.Helper()
+	if spec.immutable {
+		spec.testingTB.Fatalf(warnEventOnImmutableFormat, `Focus`)
+	}
+	Focus().setup(spec)
+	spec.registerFocus()
+}
+
+const (
+	focusSkipMessage       = `skipped because other tests are focused in the specification`
+	focusForbiddenMessage  = `focused specs are forbidden with %s, please remove the Focus before merging`
+	focusAfterGroupMessage = `Focus is used after testing groups that already ran, ` +
+		`please declare the focused spec before the testing groups or focus the group itself`
+)
+
+func (spec *Spec) registerFocus() {
+	spec.testingTB.Helper()
+	if !spec.focused {
+		return
+	}
+	root := spec.list()[0]
+	if !root.hasFocus && root.hasFinishedGroup() && !isFocusForbidden() {
+		spec.testingTB.Fatalf(focusAfterGroupMessage)
+	}
+	root.hasFocus = true
+}
+
+// hasFinishedGroup tells if a testing group of the specification tree already ran its tests.
+func (spec *Spec) hasFinishedGroup() bool {
+	var finished bool
+	spec.acceptVisitor(visitorFunc(func(s *Spec) {
+		if s.finished {
+			finished = true
+		}
+	}))
+	return finished
+}
+
+func (spec *Spec) isFocused() bool {
+	for _, s := range spec.list() {
+		if s.focused {
+			return true
+		}
+	}
+	return false
+}
+
+func (spec *Spec) isSkippedByFocus() bool {
+	root := spec.list()[0]
+	return root.hasFocus && !isFocusForbidden() && !spec.isFocused()
+}
+
+func (spec *Spec) checkFocusForbidden() {
+	spec.testingTB.Helper()
+	if spec.parent == nil && !spec.finished && spec.hasFocus && isFocusForbidden() {
+		spec.testingTB.Errorf(focusForbiddenMessage, EnvKeyFocusForbidden)
+	}
+}
+
+func isFocusForbidden() bool {
+	raw, ok := os.LookupEnv(EnvKeyFocusForbidden)
+	if !ok || raw == `` {
+		return false
+	}
+	forbidden, err := strconv.ParseBool(raw)
+	if err != nil {
+		return true
+	}
+	return forbidden
+}
@@ -0,0 +1,190 @@
+package testcase
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// goroutineLeakGracePeriod is how long the goroutines started by the test have to finish after the teardown.
+	goroutineLeakGracePeriod = 500 * time.Millisecond
+	goroutineLeakPollDelay   = 10 * time.Millisecond
+)
+
+var (
+	goroutineHeaderRgx  = regexp.MustCompile(`^goroutine (\d+) `)
+	goroutineCreatorRgx = regexp.MustCompile(`(?m)^created by .* in goroutine (\d+)$`)
+)
+
+// defaultIgnoredGoroutines are the goroutines of the testing package,
+// like the goroutine of a subtest, which are not leaked by the test itself.
+var defaultIgnoredGoroutines = []*regexp.Regexp{
+	regexp.MustCompile(`(?m)^testing\.tRunner\(`),
+}
+
+func (spec *Spec) isDetectingGoroutineLeaks() bool {
+	spec.testingTB.Helper()
+	if isGoroutineLeakDetectionEnabled() {
+		return true
+	}
+	for _, context := range spec.list() {
+		if context.detectGoroutineLeaks {
+			return true
+		}
+	}
+	return false
+}
+
+func isGoroutineLeakDetectionEnabled() bool {
+	raw, ok := os.LookupEnv(EnvKeyGoroutineLeaks)
+	if !ok || raw == `` {
+		return false
+	}
+	enabled, err := strconv.ParseBool(raw)
+	return err != nil || enabled
+}
+
+func (spec *Spec) lookupIgnoredGoroutines() []*regexp.Regexp {
+	spec.testingTB.Helper()
+	patterns := append([]*regexp.Regexp{}, defaultIgnoredGoroutines...)
+	for _, context := range spec.list() {
+		patterns = append(patterns, context.ignoredGoroutines...)
+	}
+	return patterns
+}
+
+// detectGoroutineLeaks takes a snapshot of the running goroutines,
+// and returns a function that fails the test when the goroutines started by the test are still running.
+// It must be called from the goroutine that executes the test.
+func (t *T) detectGoroutineLeaks() func() {
+	t.TB.Helper()
+	if t.benchmark != nil || !t.spec.isDetectingGoroutineLeaks() {
+		return func() {}
+	}
+	var (
+		testGoroutine = currentGoroutineID()
+		before        = make(map[int64]struct{})
+		parallel      = t.spec.isParallel()
+		ignored       = t.spec.lookupIgnoredGoroutines()
+	)
+	for _, g := range parseGoroutines(goroutineDump()) {
+		before[g.id] = struct{}{}
+	}
+	return func() {
+		t.TB.Helper()
+		var leaked []goroutine
+		deadline := time.Now().Add(goroutineLeakGracePeriod)
+		for {
+			leaked = leakedGoroutines(parseGoroutines(goroutineDump()), before, testGoroutine, parallel, ignored)
+			if len(leaked) == 0 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(goroutineLeakPollDelay)
+		}
+		if len(leaked) == 0 {
+			return
+		}
+		var msg strings.Builder
+		_, _ = fmt.Fprintf(&msg, "%d goroutine(s) leaked by the test, which are still running %s after the teardown:\n",
+			len(leaked), goroutineLeakGracePeriod)
+		for _, g := range leaked {
+			_, _ = fmt.Fprintf(&msg, "\n%s\n", g.stack)
+		}
+		t.Error(msg.String())
+	}
+}
+
+type goroutine struct {
+	id int64
+	// createdBy is the id of the goroutine that started this goroutine,
+	// or zero when it is unknown.
+	createdBy int64
+	stack     string
+}
+
+func parseGoroutines(dump string) []goroutine {
+	var goroutines []goroutine
+	for _, stack := range strings.Split(strings.TrimSpace(dump), "\n\n") {
+		m := goroutineHeaderRgx.FindStringSubmatch(stack)
+		if m == nil {
+			continue
+		}
+		g := goroutine{stack: stack}
+		g.id, _ = strconv.ParseInt(m[1], 10, 64)
+		if m := goroutineCreatorRgx.FindStringSubmatch(stack); m != nil {
+			g.createdBy, _ = strconv.ParseInt(m[1], 10, 64)
+		}
+		goroutines = append(goroutines, g)
+	}
+	return goroutines
+}
+
+// leakedGoroutines selects the goroutines which were started during the test by the test's goroutine tree.
+//
+// The goroutine tree is walked through the creator of the goroutines.
+// When the tree can't be followed, because an intermediate goroutine already finished,
+// or the go runtime doesn't report the creator of a goroutine,
+// the goroutine is only attributed to the test when no parallel test could have started it.
+func leakedGoroutines(current []goroutine, before map[int64]struct{}, testGoroutine int64, parallel bool, ignored []*regexp.Regexp) []goroutine {
+	running := make(map[int64]goroutine, len(current))
+	for _, g := range current {
+		running[g.id] = g
+	}
+	startedByTest := func(g goroutine) bool {
+		for seen := make(map[int64]struct{}); ; {
+			switch creator, ok := running[g.createdBy]; {
+			case g.createdBy == 0:
+				return !parallel
+			case g.createdBy == testGoroutine:
+				return true
+			case !ok:
+				return !parallel
+			default:
+				if _, ok := before[creator.id]; ok {
+					return false
+				}
+				if _, ok := seen[creator.id]; ok {
+					return false
+				}
+				seen[creator.id] = struct{}{}
+				g = creator
+			}
+		}
+	}
+	var leaked []goroutine
+	for _, g := range current {
+		if _, ok := before[g.id]; ok || g.id == testGoroutine {
+			continue
+		}
+		if isIgnoredGoroutine(g, ignored) || !startedByTest(g) {
+			continue
+		}
+		leaked = append(leaked, g)
+	}
+	return leaked
+}
+
+func isIgnoredGoroutine(g goroutine, patterns []*regexp.Regexp) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(g.stack) {
+			return true
+		}
+	}
+	return false
+}
+
+func currentGoroutineID() int64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	m := goroutineHeaderRgx.FindSubmatch(buf)
+	if m == nil {
+		return 0
+	}
+	id, _ := strconv.ParseInt(string(m[1]), 10, 64)
+	return id
+}
@@ -0,0 +1,102 @@
+package testcase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal"
+)
+
+func leakingWorker(done chan struct{}) { <-done }
+
+func TestDetectGoroutineLeaks(t *testing.T) {
+	t.Run(`when the test leaves a goroutine behind, the test fails with its stack`, func(t *testing.T) {
+		done := make(chan struct{})
+		defer close(done)
+		stub := &internal.StubTB{}
+		s := NewSpec(stub, DetectGoroutineLeaks())
+		s.Test(``, func(t *T) { go leakingWorker(done) })
+		stub.Finish()
+
+		assert.Must(t).True(stub.IsFailed)
+		logs := strings.Join(stub.Logs, "\n")
+		assert.Must(t).Contain(logs, `1 goroutine(s) leaked by the test`)
+		assert.Must(t).Contain(logs, `testcase.leakingWorker(`)
+		assert.Must(t).Contain(logs, `created by`)
+	})
+
+	t.Run(`when the goroutine finishes during the teardown or the grace period, the test passes`, func(t *testing.T) {
+		stub := &internal.StubTB{}
+		s := NewSpec(stub, DetectGoroutineLeaks())
+		s.Test(``, func(t *T) {
+			done := make(chan struct{})
+			t.Defer(func() { close(done) })
+			go leakingWorker(done)
+			go func() {}()
+		})
+		stub.Finish()
+
+		assert.Must(t).True(!stub.IsFailed)
+	})
+
+	t.Run(`when the goroutine matches an ignored stack pattern, the test passes`, func(t *testing.T) {
+		done := make(chan struct{})
+		defer close(done)
+		stub := &internal.StubTB{}
+		s := NewSpec(stub, DetectGoroutineLeaks())
+		s.Context(``, func(s *Spec) {
+			s.Test(``, func(t *T) { go leakingWorker(done) })
+		}, IgnoreGoroutines(`testcase\.leakingWorker`))
+		stub.Finish()
+
+		assert.Must(t).True(!stub.IsFailed)
+	})
+
+	t.Run(`when the detection is enabled with the environment variable, the leak is reported`, func(t *testing.T) {
+		SetEnv(t, EnvKeyGoroutineLeaks, `true`)
+		done := make(chan struct{})
+		defer close(done)
+		stub := &internal.StubTB{}
+		s := NewSpec(stub)
+		s.Test(``, func(t *T) { go leakingWorker(done) })
+		stub.Finish()
+
+		assert.Must(t).True(stub.IsFailed)
+	})
+
+	t.Run(`when the detection is not enabled, the leak is ignored`, func(t *testing.T) {
+		done := make(chan struct{})
+		defer close(done)
+		stub := &internal.StubTB{}
+		s := NewSpec(stub)
+		s.Test(``, func(t *T) { go leakingWorker(done) })
+		stub.Finish()
+
+		assert.Must(t).True(!stub.IsFailed)
+	})
+
+	t.Run(`when the test is parallel, only the goroutines of the test's goroutine tree are reported`, func(t *testing.T) {
+		done := make(chan struct{})
+		defer close(done)
+		spawn := make(chan struct{})
+		go func() { // simulates a concurrently running test
+			for range spawn {
+				go leakingWorker(done)
+			}
+		}()
+		defer close(spawn)
+
+		stub := &internal.StubTB{}
+		s := NewSpec(stub, DetectGoroutineLeaks())
+		s.Parallel()
+		s.Test(``, func(t *T) {
+			spawn <- struct{}{}
+			go leakingWorker(done)
+		})
+		stub.Finish()
+
+		assert.Must(t).True(stub.IsFailed)
+		assert.Must(t).Contain(strings.Join(stub.Logs, "\n"), `1 goroutine(s) leaked by the test`)
+	})
+}
@@ -1,6 +1,7 @@
 package testcase
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -9,6 +10,13 @@ unless you create a new spec with the previously mentioned calls`
 
 type hookBlock func(*T) func()
 
+// hook is a Before, After or Around hook along with where it was declared.
+type hook struct {
+	kind     string
+	location string
+	block    hookBlock
+}
+
 // Before give you the ability to run a block before each test case.
 // This is ideal for doing clean ahead before each test case.
 // The received *testing.T object is the same as the Test block *testing.T object
@@ -16,7 +24,7 @@ type hookBlock func(*T) func()
 // All setup block is stackable.
 func (spec *Spec) Before(beforeBlock block) {
 	spec.testingTB.Helper()
-	spec.Around(func(t *T) func() {
+	spec.around(`Before`, func(t *T) func() {
 		beforeBlock(t)
 		return func() {}
 	})
@@ -29,7 +37,7 @@ func (spec *Spec) Before(beforeBlock block) {
 // All setup block is stackable.
 func (spec *Spec) After(afterBlock block) {
 	spec.testingTB.Helper()
-	spec.Around(func(t *T) func() {
+	spec.around(`After`, func(t *T) func() {
 		return func() { afterBlock(t) }
 	})
 }
@@ -40,11 +48,20 @@ func (spec *Spec) After(afterBlock block) {
 // This hook applied to this scope and anything that is nested from here.
 // All setup block is stackable.
 func (spec *Spec) Around(aroundBlock hookBlock) {
+	spec.testingTB.Helper()
+	spec.around(`Around`, aroundBlock)
+}
+
+func (spec *Spec) around(kind string, blk hookBlock) {
 	spec.testingTB.Helper()
 	if spec.immutable {
 		spec.testingTB.Fatal(hookWarning)
 	}
-	spec.hooks.Around = append(spec.hooks.Around, aroundBlock)
+	spec.hooks.Around = append(spec.hooks.Around, hook{
+		kind:     kind,
+		location: spec.callerLocationName(1),
+		block:    blk,
+	})
 }
 
 // BeforeAll give you the ability to create a hook
@@ -77,3 +94,10 @@ func (spec *Spec) AroundAll(blk func(tb testing.TB) func()) {
 	aroundAll := func() func() { return blk(spec.testingTB) }
 	spec.hooks.AroundAll = append(spec.hooks.AroundAll, aroundAll)
 }
+
+func (spec *Spec) hookScopeName() string {
+	if spec.description == `` {
+		return `the root spec`
+	}
+	return fmt.Sprintf(`%q`, spec.description)
+}
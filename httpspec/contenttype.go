@@ -6,6 +6,6 @@ import (
 
 func ContentTypeIsJSON(s *testcase.Spec) {
 	s.Before(func(t *testcase.T) {
-		HeaderGet(t).Set(`Content-Type`, `application/json`)
+		Header.Get(t).Set(`Content-Type`, `application/json`)
 	})
 }
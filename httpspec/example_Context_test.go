@@ -17,7 +17,7 @@ func ExampleLetContext_withValue() {
 		// This approach can help you representing middleware prerequisites.
 		// Use httpspec.Context.Set only if you can't solve your goal
 		// with httpspec.Context.Let or httpspec.Context.LetValue.
-		httpspec.Context.Set(t, context.WithValue(httpspec.ContextGet(t), `foo`, `bar`))
+		httpspec.Context.Set(t, context.WithValue(httpspec.Context.Get(t), `foo`, `bar`))
 	})
 
 	s.Test(`the *http.Request#Context() will have foo-bar`, func(t *testcase.T) {
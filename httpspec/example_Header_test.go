@@ -14,7 +14,7 @@ func ExampleHeader() {
 
 	s.Before(func(t *testcase.T) {
 		// this is ideal to represent query string inputs
-		httpspec.HeaderGet(t).Set(`Foo`, `bar`)
+		httpspec.Header.Get(t).Set(`Foo`, `bar`)
 	})
 
 	s.Test(`the *http.Request URL QueryGet will have 'Foo: bar'`, func(t *testcase.T) {
@@ -14,7 +14,7 @@ func ExampleQuery() {
 
 	s.Before(func(t *testcase.T) {
 		// this is ideal to represent query string inputs
-		httpspec.QueryGet(t).Set(`foo`, `bar`)
+		httpspec.Query.Get(t).Set(`foo`, `bar`)
 	})
 
 	s.Test(`the *http.Request URL QueryGet will have foo=bar`, func(t *testcase.T) {
@@ -11,48 +11,57 @@ import (
 )
 
 var (
-	Handler = testcase.Var{Name: `httpspec:Handler`}
-	Context = testcase.Var{Name: `httpspec:Context`, Init: func(t *testcase.T) interface{} {
-		return context.Background()
+	Handler = testcase.VarOf[http.Handler]{Name: `httpspec:Handler`}
+	// Context is the request context, which by default is the context of the test, see testcase.T.Context.
+	Context = testcase.VarOf[context.Context]{Name: `httpspec:Context`, Init: func(t *testcase.T) context.Context {
+		return t.Context()
 	}}
-	Method = testcase.Var{Name: `httpspec:Method`, Init: func(t *testcase.T) interface{} {
+	Method = testcase.VarOf[string]{Name: `httpspec:Method`, Init: func(t *testcase.T) string {
 		return http.MethodGet
 	}}
-	Path = testcase.Var{Name: `httpspec:Path`, Init: func(t *testcase.T) interface{} {
+	Path = testcase.VarOf[string]{Name: `httpspec:Path`, Init: func(t *testcase.T) string {
 		return `/`
 	}}
-	Body = testcase.Var{Name: `httpspec:Body`, Init: func(t *testcase.T) interface{} {
+	// Body is the request payload, which can be an io.Reader,
+	// or a value that is encoded based on the Content-Type Header.
+	Body = testcase.VarOf[interface{}]{Name: `httpspec:Body`, Init: func(t *testcase.T) interface{} {
 		return &bytes.Buffer{}
 	}}
-	Query = testcase.Var{Name: `httpspec:QueryGet`, Init: func(t *testcase.T) interface{} {
+	Query = testcase.VarOf[url.Values]{Name: `httpspec:QueryGet`, Init: func(t *testcase.T) url.Values {
 		return url.Values{}
 	}}
-	Header = testcase.Var{Name: `httpspec:HeaderGet`, Init: func(t *testcase.T) interface{} {
+	Header = testcase.VarOf[http.Header]{Name: `httpspec:HeaderGet`, Init: func(t *testcase.T) http.Header {
 		return http.Header{}
 	}}
 )
 
 // ContextGet allow to retrieve the current test scope's request context.
+//
+// Deprecated: use Context.Get instead.
 func ContextGet(t *testcase.T) context.Context {
-	return Context.Get(t).(context.Context)
+	return Context.Get(t)
 }
 
 // QueryGet allows you to retrieve the current test scope's http PathGet query that will be used for ServeHTTP.
 // In a Before Block you can access the query and then specify the values in it.
+//
+// Deprecated: use Query.Get instead.
 func QueryGet(t *testcase.T) url.Values {
-	return Query.Get(t).(url.Values)
+	return Query.Get(t)
 }
 
 // HeaderGet allows you to set the current test scope's http PathGet for ServeHTTP.
+//
+// Deprecated: use Header.Get instead.
 func HeaderGet(t *testcase.T) http.Header {
-	return Header.Get(t).(http.Header)
+	return Header.Get(t)
 }
 
 // HandlerLet prepares the current testcase spec scope to be ready for http handler testing.
 //
 // You define your spec subject with this and all the request will be pointed towards this.
 func HandlerLet(s *testcase.Spec, subject func(t *testcase.T) http.Handler) {
-	Handler.Let(s, func(t *testcase.T) interface{} { return subject(t) })
+	Handler.Let(s, subject)
 }
 
 // ServeHTTP will make a request to the spec context
@@ -64,15 +73,15 @@ func HandlerLet(s *testcase.Spec, subject func(t *testcase.T) http.Handler) {
 //
 func ServeHTTP(t *testcase.T) *httptest.ResponseRecorder {
 	w := httptest.NewRecorder()
-	target, _ := url.Parse(Path.Get(t).(string))
-	target.RawQuery = QueryGet(t).Encode()
+	target, _ := url.Parse(Path.Get(t))
+	target.RawQuery = Query.Get(t).Encode()
 	if isDebugEnabled(t) {
-		t.Log(`MethodGet:`, Method.Get(t).(string))
+		t.Log(`MethodGet:`, Method.Get(t))
 		t.Log(`PathGet`, target.String())
 	}
-	r := httptest.NewRequest(Method.Get(t).(string), target.String(), bodyToIOReader(t))
-	r = r.WithContext(Context.Get(t).(context.Context))
-	r.Header = HeaderGet(t)
-	Handler.Get(t).(http.Handler).ServeHTTP(w, r)
+	r := httptest.NewRequest(Method.Get(t), target.String(), bodyToIOReader(t))
+	r = r.WithContext(Context.Get(t))
+	r.Header = Header.Get(t)
+	Handler.Get(t).ServeHTTP(w, r)
 	return w
 }
@@ -62,13 +62,20 @@ func Test_handlerSpec(t *testing.T) {
 		})
 	})
 
+	s.When(`context is not defined`, func(s *testcase.Spec) {
+		s.Then(`the context of the test will be passed for the request`, func(t *testcase.T) {
+			httpspec.ServeHTTP(t)
+			t.Must.Equal(t.Context(), ctx)
+		})
+	})
+
 	s.When(`context defined`, func(s *testcase.Spec) {
 		var expected = context.WithValue(context.Background(), `key`, `value`)
-		httpspec.Context.Let(s, func(t *testcase.T) interface{} { return expected })
+		httpspec.Context.Let(s, func(t *testcase.T) context.Context { return expected })
 
 		s.And(`using context key-value is added with testcase.T#Let + httpspec.ContextVarName`, func(s *testcase.Spec) {
 			s.Before(func(t *testcase.T) {
-				httpspec.Context.Set(t, context.WithValue(httpspec.ContextGet(t), `foo`, `bar`))
+				httpspec.Context.Set(t, context.WithValue(httpspec.Context.Get(t), `foo`, `bar`))
 			})
 
 			s.Then(`in this scope the key-values of the context will be updated`, func(t *testcase.T) {
@@ -87,10 +94,10 @@ func Test_handlerSpec(t *testing.T) {
 
 	s.When(`query populated during Spec#Before`, func(s *testcase.Spec) {
 		s.Before(func(t *testcase.T) {
-			httpspec.QueryGet(t).Set(`hello`, `world`)
-			httpspec.QueryGet(t).Add(`l`, `a`)
-			httpspec.QueryGet(t).Add(`l`, `b`)
-			httpspec.QueryGet(t).Add(`l`, `c`)
+			httpspec.Query.Get(t).Set(`hello`, `world`)
+			httpspec.Query.Get(t).Add(`l`, `a`)
+			httpspec.Query.Get(t).Add(`l`, `b`)
+			httpspec.Query.Get(t).Add(`l`, `c`)
 		})
 
 		s.Then(`it will pass the query to the request`, func(t *testcase.T) {
@@ -102,10 +109,10 @@ func Test_handlerSpec(t *testing.T) {
 
 	s.When(`header populated during Spec#Before`, func(s *testcase.Spec) {
 		s.Before(func(t *testcase.T) {
-			httpspec.HeaderGet(t).Set(`Hello`, `world`)
-			httpspec.HeaderGet(t).Add(`L`, `a`)
-			httpspec.HeaderGet(t).Add(`L`, `b`)
-			httpspec.HeaderGet(t).Add(`L`, `c`)
+			httpspec.Header.Get(t).Set(`Hello`, `world`)
+			httpspec.Header.Get(t).Add(`L`, `a`)
+			httpspec.Header.Get(t).Add(`L`, `b`)
+			httpspec.Header.Get(t).Add(`L`, `c`)
 		})
 
 		s.Then(`it will HandlerLet the headers for the request`, func(t *testcase.T) {
@@ -124,7 +131,7 @@ func Test_handlerSpec(t *testing.T) {
 	})
 
 	s.When(`PathGet is defined with PathLet`, func(s *testcase.Spec) {
-		httpspec.Path.Let(s, func(t *testcase.T) interface{} { return `/hello/world` })
+		httpspec.Path.Let(s, func(t *testcase.T) string { return `/hello/world` })
 
 		s.Then(`it will call request with the given PathGet`, func(t *testcase.T) {
 			httpspec.ServeHTTP(t)
@@ -149,7 +156,7 @@ func Test_handlerSpec(t *testing.T) {
 	})
 
 	s.When(`MethodGet is defined with MethodLet`, func(s *testcase.Spec) {
-		httpspec.Method.Let(s, func(t *testcase.T) interface{} { return http.MethodPost })
+		httpspec.Method.Let(s, func(t *testcase.T) string { return http.MethodPost })
 
 		s.Then(`it will use the http MethodGet for the request`, func(t *testcase.T) {
 			httpspec.ServeHTTP(t)
@@ -203,7 +210,7 @@ func Test_handlerSpec(t *testing.T) {
 
 				s.And(`form encoding is used`, func(s *testcase.Spec) {
 					s.Before(func(t *testcase.T) {
-						httpspec.HeaderGet(t).Set(`Content-Type`, `application/x-www-form-urlencoded`)
+						httpspec.Header.Get(t).Set(`Content-Type`, `application/x-www-form-urlencoded`)
 					})
 
 					s.Then(`it will use over simplified basic form url encoding`, func(t *testcase.T) {
@@ -215,7 +222,7 @@ func Test_handlerSpec(t *testing.T) {
 
 				s.And(`json encoding is used for the request`, func(s *testcase.Spec) {
 					s.Before(func(t *testcase.T) {
-						httpspec.HeaderGet(t).Set(`Content-Type`, `application/json`)
+						httpspec.Header.Get(t).Set(`Content-Type`, `application/json`)
 					})
 
 					s.Then(`it will use json encoding`, func(t *testcase.T) {
@@ -235,7 +242,7 @@ func Test_handlerSpec(t *testing.T) {
 
 				s.And(`form encoding is used`, func(s *testcase.Spec) {
 					s.Before(func(t *testcase.T) {
-						httpspec.HeaderGet(t).Set(`Content-Type`, `application/x-www-form-urlencoded`)
+						httpspec.Header.Get(t).Set(`Content-Type`, `application/x-www-form-urlencoded`)
 					})
 
 					s.Then(`it will use over simplified basic form url encoding`, func(t *testcase.T) {
@@ -247,7 +254,7 @@ func Test_handlerSpec(t *testing.T) {
 
 				s.And(`json encoding is used for the request`, func(s *testcase.Spec) {
 					s.Before(func(t *testcase.T) {
-						httpspec.HeaderGet(t).Set(`Content-Type`, `application/json`)
+						httpspec.Header.Get(t).Set(`Content-Type`, `application/json`)
 					})
 
 					s.Then(`it will use json encoding`, func(t *testcase.T) {
@@ -271,7 +278,7 @@ func Test_handlerSpec(t *testing.T) {
 
 				s.And(`form encoding is used`, func(s *testcase.Spec) {
 					s.Before(func(t *testcase.T) {
-						httpspec.HeaderGet(t).Set(`Content-Type`, `application/x-www-form-urlencoded`)
+						httpspec.Header.Get(t).Set(`Content-Type`, `application/x-www-form-urlencoded`)
 					})
 
 					s.Then(`it will use over simplified basic form url encoding`, func(t *testcase.T) {
@@ -283,7 +290,7 @@ func Test_handlerSpec(t *testing.T) {
 
 				s.And(`json encoding is used for the request`, func(s *testcase.Spec) {
 					s.Before(func(t *testcase.T) {
-						httpspec.HeaderGet(t).Set(`Content-Type`, `application/json`)
+						httpspec.Header.Get(t).Set(`Content-Type`, `application/json`)
 					})
 
 					s.Then(`it will use json encoding`, func(t *testcase.T) {
@@ -304,7 +311,7 @@ func Test_handlerSpec(t *testing.T) {
 
 			s.And(`form encoding is used`, func(s *testcase.Spec) {
 				s.Before(func(t *testcase.T) {
-					httpspec.HeaderGet(t).Set(`Content-Type`, `application/x-www-form-urlencoded`)
+					httpspec.Header.Get(t).Set(`Content-Type`, `application/x-www-form-urlencoded`)
 				})
 
 				s.Then(`it will use over simplified basic form url encoding`, func(t *testcase.T) {
@@ -316,7 +323,7 @@ func Test_handlerSpec(t *testing.T) {
 
 			s.And(`json encoding is used for the request`, func(s *testcase.Spec) {
 				s.Before(func(t *testcase.T) {
-					httpspec.HeaderGet(t).Set(`Content-Type`, `application/json`)
+					httpspec.Header.Get(t).Set(`Content-Type`, `application/json`)
 				})
 
 				s.Then(`it will use json encoding`, func(t *testcase.T) {
@@ -336,7 +343,7 @@ func Test_handlerSpec(t *testing.T) {
 
 			s.And(`form encoding is used`, func(s *testcase.Spec) {
 				s.Before(func(t *testcase.T) {
-					httpspec.HeaderGet(t).Set(`Content-Type`, `application/x-www-form-urlencoded`)
+					httpspec.Header.Get(t).Set(`Content-Type`, `application/x-www-form-urlencoded`)
 				})
 
 				s.Then(`it will use over simplified basic form url encoding`, func(t *testcase.T) {
@@ -348,7 +355,7 @@ func Test_handlerSpec(t *testing.T) {
 
 			s.And(`json encoding is used for the request`, func(s *testcase.Spec) {
 				s.Before(func(t *testcase.T) {
-					httpspec.HeaderGet(t).Set(`Content-Type`, `application/json`)
+					httpspec.Header.Get(t).Set(`Content-Type`, `application/json`)
 				})
 
 				s.Then(`it will use json encoding`, func(t *testcase.T) {
@@ -368,7 +375,7 @@ func Test_handlerSpec(t *testing.T) {
 
 			s.And(`form encoding is used`, func(s *testcase.Spec) {
 				s.Before(func(t *testcase.T) {
-					httpspec.HeaderGet(t).Set(`Content-Type`, `application/x-www-form-urlencoded`)
+					httpspec.Header.Get(t).Set(`Content-Type`, `application/x-www-form-urlencoded`)
 				})
 
 				s.Then(`it will use over simplified basic form url encoding`, func(t *testcase.T) {
@@ -380,7 +387,7 @@ func Test_handlerSpec(t *testing.T) {
 
 			s.And(`json encoding is used for the request`, func(s *testcase.Spec) {
 				s.Before(func(t *testcase.T) {
-					httpspec.HeaderGet(t).Set(`Content-Type`, `application/json`)
+					httpspec.Header.Get(t).Set(`Content-Type`, `application/json`)
 				})
 
 				s.Then(`it will use json encoding`, func(t *testcase.T) {
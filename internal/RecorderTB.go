@@ -117,6 +117,13 @@ func (rtb *RecorderTB) Helper() {
 	})
 }
 
+func (rtb *RecorderTB) Name() string {
+	if rtb.TB == nil {
+		return ``
+	}
+	return rtb.TB.Name()
+}
+
 func (rtb *RecorderTB) Log(args ...interface{}) {
 	rtb.record(func(r *record) {
 		r.Forward = func() {
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"reflect"
+)
+
+// DeepCopy makes a copy of the value, where the referenced values, like pointers, slices and maps are copied as well,
+// so mutating the copy will not affect the original value.
+//
+// Unexported struct fields are copied by value,
+// and functions and channels are shared between the original and the copy.
+func DeepCopy(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	c := deepCopier{visited: make(map[uintptr]reflect.Value)}
+	return c.copy(reflect.ValueOf(v)).Interface()
+}
+
+type deepCopier struct {
+	// visited holds the copies of the already copied pointers,
+	// so cyclic structures and shared references are preserved in the copy.
+	visited map[uintptr]reflect.Value
+}
+
+func (c deepCopier) copy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		if cp, ok := c.visited[v.Pointer()]; ok {
+			return cp
+		}
+		cp := reflect.New(v.Type().Elem())
+		c.visited[v.Pointer()] = cp
+		cp.Elem().Set(c.copy(v.Elem()))
+		return cp
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(c.copy(v.Elem()))
+		return cp
+
+	case reflect.Struct:
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if field := cp.Field(i); field.CanSet() {
+				field.Set(c.copy(v.Field(i)))
+			}
+		}
+		return cp
+
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return cp
+
+	case reflect.Array:
+		cp := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			cp.Index(i).Set(c.copy(v.Index(i)))
+		}
+		return cp
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		cp := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			cp.SetMapIndex(c.copy(iter.Key()), c.copy(iter.Value()))
+		}
+		return cp
+
+	default:
+		return v
+	}
+}
@@ -0,0 +1,68 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal"
+)
+
+func TestDeepCopy(t *testing.T) {
+	type Node struct {
+		Value    int
+		Tags     []string
+		Meta     map[string]int
+		Next     *Node
+		Any      interface{}
+		internal string
+	}
+
+	t.Run(`nil`, func(t *testing.T) {
+		assert.Must(t).Nil(internal.DeepCopy(nil))
+	})
+	t.Run(`primitives are returned as is`, func(t *testing.T) {
+		assert.Must(t).Equal(42, internal.DeepCopy(42))
+		assert.Must(t).Equal(`foo`, internal.DeepCopy(`foo`))
+	})
+	t.Run(`the copy is equal to the original`, func(t *testing.T) {
+		og := Node{
+			Value:    1,
+			Tags:     []string{`a`, `b`},
+			Meta:     map[string]int{`x`: 1},
+			Next:     &Node{Value: 2},
+			Any:      []int{1, 2, 3},
+			internal: `internal`,
+		}
+		assert.Must(t).Equal(og, internal.DeepCopy(og))
+	})
+	t.Run(`mutating the copy doesn't affect the original`, func(t *testing.T) {
+		og := &Node{
+			Tags: []string{`a`},
+			Meta: map[string]int{`x`: 1},
+			Next: &Node{Value: 2},
+			Any:  []int{1},
+		}
+		cp := internal.DeepCopy(og).(*Node)
+		cp.Tags[0] = `changed`
+		cp.Meta[`x`] = 42
+		cp.Next.Value = 42
+		cp.Any.([]int)[0] = 42
+		assert.Must(t).Equal(`a`, og.Tags[0])
+		assert.Must(t).Equal(1, og.Meta[`x`])
+		assert.Must(t).Equal(2, og.Next.Value)
+		assert.Must(t).Equal(1, og.Any.([]int)[0])
+	})
+	t.Run(`cyclic references are preserved`, func(t *testing.T) {
+		og := &Node{Value: 1}
+		og.Next = og
+		cp := internal.DeepCopy(og).(*Node)
+		assert.Must(t).True(cp != og)
+		assert.Must(t).True(cp.Next == cp)
+	})
+	t.Run(`arrays`, func(t *testing.T) {
+		og := [2][]int{{1}, {2}}
+		cp := internal.DeepCopy(og).([2][]int)
+		cp[0][0] = 42
+		assert.Must(t).Equal(1, og[0][0])
+	})
+}
@@ -0,0 +1,156 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Pretty formats a value in a Go syntax like multi-line representation,
+// where each element of a struct, map or slice is placed on its own line.
+// Unexported struct fields are included, and cyclic references are detected.
+func Pretty(v interface{}) string {
+	p := prettyPrinter{visited: make(map[uintptr]struct{})}
+	p.print(reflect.ValueOf(v), 0)
+	return p.buf.String()
+}
+
+const prettyMaxDepth = 32
+
+type prettyPrinter struct {
+	buf     strings.Builder
+	visited map[uintptr]struct{}
+}
+
+func (p *prettyPrinter) print(rv reflect.Value, depth int) {
+	if !rv.IsValid() {
+		p.buf.WriteString(`nil`)
+		return
+	}
+	if prettyMaxDepth < depth {
+		p.buf.WriteString(`...`)
+		return
+	}
+	if rv.Type() == reflect.TypeOf(time.Time{}) && rv.CanInterface() {
+		_, _ = fmt.Fprintf(&p.buf, `time.Time(%s)`, rv.Interface())
+		return
+	}
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			_, _ = fmt.Fprintf(&p.buf, `(%s)(nil)`, rv.Type())
+			return
+		}
+		if _, ok := p.visited[rv.Pointer()]; ok {
+			_, _ = fmt.Fprintf(&p.buf, `(%s)(cyclic reference)`, rv.Type())
+			return
+		}
+		p.visited[rv.Pointer()] = struct{}{}
+		defer delete(p.visited, rv.Pointer())
+		p.buf.WriteString(`&`)
+		p.print(rv.Elem(), depth)
+
+	case reflect.Interface:
+		if rv.IsNil() {
+			p.buf.WriteString(`nil`)
+			return
+		}
+		p.print(rv.Elem(), depth)
+
+	case reflect.Struct:
+		p.buf.WriteString(rv.Type().String())
+		if rv.NumField() == 0 {
+			p.buf.WriteString(`{}`)
+			return
+		}
+		p.buf.WriteString("{\n")
+		for i := 0; i < rv.NumField(); i++ {
+			p.indent(depth + 1)
+			_, _ = fmt.Fprintf(&p.buf, `%s: `, rv.Type().Field(i).Name)
+			p.print(rv.Field(i), depth+1)
+			p.buf.WriteString(",\n")
+		}
+		p.indent(depth)
+		p.buf.WriteString(`}`)
+
+	case reflect.Map:
+		if rv.IsNil() {
+			_, _ = fmt.Fprintf(&p.buf, `%s(nil)`, rv.Type())
+			return
+		}
+		p.buf.WriteString(rv.Type().String())
+		if rv.Len() == 0 {
+			p.buf.WriteString(`{}`)
+			return
+		}
+		type entry struct{ key, value string }
+		var entries []entry
+		for _, key := range rv.MapKeys() {
+			var kp, vp = p.sub(), p.sub()
+			kp.print(key, depth+1)
+			vp.print(rv.MapIndex(key), depth+1)
+			entries = append(entries, entry{key: kp.buf.String(), value: vp.buf.String()})
+		}
+		sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+		p.buf.WriteString("{\n")
+		for _, e := range entries {
+			p.indent(depth + 1)
+			_, _ = fmt.Fprintf(&p.buf, "%s: %s,\n", e.key, e.value)
+		}
+		p.indent(depth)
+		p.buf.WriteString(`}`)
+
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			_, _ = fmt.Fprintf(&p.buf, `%s(nil)`, rv.Type())
+			return
+		}
+		if rv.Type().Elem().Kind() == reflect.Uint8 && rv.Kind() == reflect.Slice {
+			_, _ = fmt.Fprintf(&p.buf, `%s(%q)`, rv.Type(), rv.Bytes())
+			return
+		}
+		p.buf.WriteString(rv.Type().String())
+		if rv.Len() == 0 {
+			p.buf.WriteString(`{}`)
+			return
+		}
+		p.buf.WriteString("{\n")
+		for i := 0; i < rv.Len(); i++ {
+			p.indent(depth + 1)
+			p.print(rv.Index(i), depth+1)
+			p.buf.WriteString(",\n")
+		}
+		p.indent(depth)
+		p.buf.WriteString(`}`)
+
+	case reflect.String:
+		p.buf.WriteString(strconv.Quote(rv.String()))
+	case reflect.Bool:
+		p.buf.WriteString(strconv.FormatBool(rv.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		p.buf.WriteString(strconv.FormatInt(rv.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		p.buf.WriteString(strconv.FormatUint(rv.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		p.buf.WriteString(strconv.FormatFloat(rv.Float(), 'g', -1, rv.Type().Bits()))
+	case reflect.Complex64, reflect.Complex128:
+		_, _ = fmt.Fprintf(&p.buf, `%v`, rv.Complex())
+	default: // chan, func, unsafe.Pointer
+		if rv.IsNil() {
+			_, _ = fmt.Fprintf(&p.buf, `(%s)(nil)`, rv.Type())
+			return
+		}
+		_, _ = fmt.Fprintf(&p.buf, `(%s)(%#x)`, rv.Type(), rv.Pointer())
+	}
+}
+
+func (p *prettyPrinter) sub() *prettyPrinter {
+	return &prettyPrinter{visited: p.visited}
+}
+
+func (p *prettyPrinter) indent(depth int) {
+	p.buf.WriteString(strings.Repeat(`  `, depth))
+}
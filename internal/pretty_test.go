@@ -0,0 +1,51 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal"
+)
+
+func TestPretty(t *testing.T) {
+	t.Run("when given object is a scalar", func(t *testing.T) {
+		assert.Must(t).Equal(`42`, internal.Pretty(42))
+		assert.Must(t).Equal(`"foo"`, internal.Pretty(`foo`))
+		assert.Must(t).Equal(`true`, internal.Pretty(true))
+		assert.Must(t).Equal(`nil`, internal.Pretty(nil))
+	})
+
+	t.Run("when given object is a struct", func(t *testing.T) {
+		type Entity struct {
+			ID    int
+			tags  []string
+			Attrs map[string]int
+		}
+		expected := `&internal_test.Entity{
+  ID: 42,
+  tags: []string{
+    "a",
+    "b",
+  },
+  Attrs: map[string]int{
+    "x": 1,
+    "y": 2,
+  },
+}`
+		assert.Must(t).Equal(expected, internal.Pretty(&Entity{ID: 42, tags: []string{`a`, `b`}, Attrs: map[string]int{`y`: 2, `x`: 1}}))
+	})
+
+	t.Run("when given object is empty or nil", func(t *testing.T) {
+		assert.Must(t).Equal(`[]int(nil)`, internal.Pretty([]int(nil)))
+		assert.Must(t).Equal(`[]int{}`, internal.Pretty([]int{}))
+		assert.Must(t).Equal(`(*int)(nil)`, internal.Pretty((*int)(nil)))
+		assert.Must(t).Equal(`[]uint8("foo")`, internal.Pretty([]byte(`foo`)))
+	})
+
+	t.Run("when given object has a cyclic reference", func(t *testing.T) {
+		type Node struct{ Next *Node }
+		n := &Node{}
+		n.Next = n
+		assert.Must(t).Equal("&internal_test.Node{\n  Next: (*internal_test.Node)(cyclic reference),\n}", internal.Pretty(n))
+	})
+}
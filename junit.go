@@ -0,0 +1,184 @@
+package testcase
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+func getJUnitOutputPath() (string, bool) {
+	path, ok := os.LookupEnv(EnvKeyJUnitOutput)
+	return path, ok && path != ``
+}
+
+// writeJUnitReport writes the JUnit XML report of the package's specifications.
+// When the path is not an xml file path, it is used as a directory,
+// and the report file is named after the package,
+// so parallel package test runs don't overwrite each other's report.
+func writeJUnitReport(path string, pkg *reportedPackage) error {
+	if !strings.EqualFold(filepath.Ext(path), `.xml`) {
+		path = filepath.Join(path, filepath.FromSlash(pkg.path)) + `.xml`
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	bs, err := xml.MarshalIndent(newJUnitTestSuites(pkg), "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append([]byte(xml.Header), bs...), 0644)
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Time       string           `xml:"time,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+
+	duration time.Duration
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Failure    *junitMessage    `xml:"failure,omitempty"`
+	Skipped    *junitMessage    `xml:"skipped,omitempty"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Content string `xml:",chardata"`
+}
+
+func newJUnitTestSuites(pkg *reportedPackage) junitTestSuites {
+	suites := junitTestSuites{Name: pkg.path}
+	var total time.Duration
+	for _, root := range pkg.roots {
+		for _, suite := range newJUnitTestSuitesFor(pkg.path, root.name, root.spec) {
+			suites.Tests += suite.Tests
+			suites.Failures += suite.Failures
+			suites.Skipped += suite.Skipped
+			total += suite.duration
+			suites.TestSuites = append(suites.TestSuites, suite)
+		}
+	}
+	sort.SliceStable(suites.TestSuites, func(i, j int) bool {
+		return suites.TestSuites[i].Name < suites.TestSuites[j].Name
+	})
+	suites.Time = junitTime(total)
+	return suites
+}
+
+// newJUnitTestSuitesFor maps the testing groups (e.g.: Describe) of the spec tree into test suites.
+// A test belongs to the test suite of its closest testing group.
+func newJUnitTestSuitesFor(pkgPath, name string, spec *Spec) []junitTestSuite {
+	suite := junitTestSuite{Name: name}
+	var suites []junitTestSuite
+	var walk func(s *Spec)
+	walk = func(s *Spec) {
+		for _, child := range s.children {
+			switch {
+			case child.result != nil:
+				suite.add(newJUnitTestCase(pkgPath, name, child))
+				_, _, duration := child.result.get()
+				suite.duration += duration
+			case child.group != nil:
+				suites = append(suites, newJUnitTestSuitesFor(pkgPath, name+`/`+child.group.name, child)...)
+			default:
+				walk(child)
+			}
+		}
+	}
+	walk(spec)
+	if 0 < len(suite.TestCases) {
+		suite.Time = junitTime(suite.duration)
+		suites = append([]junitTestSuite{suite}, suites...)
+	}
+	return suites
+}
+
+func (suite *junitTestSuite) add(tc junitTestCase) {
+	suite.Tests++
+	if tc.Failure != nil {
+		suite.Failures++
+	}
+	if tc.Skipped != nil {
+		suite.Skipped++
+	}
+	suite.TestCases = append(suite.TestCases, tc)
+}
+
+func newJUnitTestCase(pkgPath, suiteName string, spec *Spec) junitTestCase {
+	status, reason, duration := spec.result.get()
+	tc := junitTestCase{
+		Name:      spec.descriptionPathLine(),
+		ClassName: pkgPath + `.` + suiteName,
+		Time:      junitTime(duration),
+	}
+	if tc.Name == `` {
+		tc.Name = spec.id
+	}
+
+	var props []junitProperty
+	var tags []string
+	for tag := range spec.getTagSet() {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		props = append(props, junitProperty{Name: `tag`, Value: tag})
+	}
+	if _, ok := spec.lookupRetryFlaky(); ok {
+		props = append(props, junitProperty{Name: `attempts`, Value: fmt.Sprintf(`%d`, len(spec.result.getAttempts()))})
+	}
+	if 0 < len(props) {
+		tc.Properties = &junitProperties{Properties: props}
+	}
+
+	switch status {
+	case testStatusFailed:
+		failures := spec.result.getFailures()
+		msg := &junitMessage{Content: strings.Join(failures, "\n\n")}
+		if 0 < len(failures) {
+			msg.Message = strings.SplitN(strings.TrimSpace(failures[0]), "\n", 2)[0]
+		}
+		tc.Failure = msg
+	case testStatusSkipped, testStatusNotRun:
+		tc.Skipped = &junitMessage{Message: reason}
+	case testStatusPending:
+		tc.Skipped = &junitMessage{Message: fmt.Sprintf(pendingSkipFormat, reason)}
+	}
+	return tc
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf(`%.3f`, d.Seconds())
+}
@@ -0,0 +1,212 @@
+package testcase
+
+import (
+	"encoding/xml"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal"
+)
+
+func TestSpec_junitReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), `report.xml`)
+	SetEnv(t, EnvKeyJUnitOutput, path)
+	SetEnv(t, EnvKeyOrdering, string(OrderingAsDefined))
+	UnsetEnv(t, EnvKeyDocOutput)
+	internal.SetupCacheFlush(t)
+
+	stub := &internal.StubTB{StubName: `TestSubject`}
+	s := NewSpec(stubRunner{StubTB: stub})
+	s.Tag(`unit`)
+	s.Test(`root test`, func(t *T) {})
+	s.Describe(`#Add`, func(s *Spec) {
+		s.When(`the value is positive`, func(s *Spec) {
+			s.Tag(`math`)
+			s.Then(`it increases the total`, func(t *T) {})
+			s.Then(`it reports the change`, func(t *T) { t.Must.Equal(1, 2) })
+		})
+		s.Then(`it is skipped`, func(t *T) { t.Skip(`not today`) })
+		s.Todo(`it handles overflow`)
+		var attempts int
+		s.Then(`it is flaky`, func(t *T) {
+			attempts++
+			if attempts < 3 {
+				t.FailNow()
+			}
+		}, Flaky(5))
+	})
+	stub.Finish()
+
+	bs, err := ioutil.ReadFile(path)
+	assert.Must(t).Nil(err)
+	var report junitTestSuites
+	assert.Must(t).Nil(xml.Unmarshal(bs, &report))
+
+	assert.Must(t).Equal(`github.com/adamluzsi/testcase`, report.Name)
+	assert.Must(t).Equal(6, report.Tests)
+	assert.Must(t).Equal(1, report.Failures)
+	assert.Must(t).Equal(2, report.Skipped)
+	assert.Must(t).Equal(2, len(report.TestSuites))
+
+	root := report.TestSuites[0]
+	assert.Must(t).Equal(`TestSubject`, root.Name)
+	assert.Must(t).Equal(1, root.Tests)
+	assert.Must(t).Equal(`root test`, root.TestCases[0].Name)
+
+	group := report.TestSuites[1]
+	assert.Must(t).Equal(`TestSubject/#Add`, group.Name)
+	assert.Must(t).Equal(5, group.Tests)
+	cases := make(map[string]junitTestCase)
+	for _, tc := range group.TestCases {
+		cases[tc.Name] = tc
+	}
+
+	passed := cases[`describe #Add when the value is positive then it increases the total`]
+	assert.Must(t).Equal(`github.com/adamluzsi/testcase.TestSubject/#Add`, passed.ClassName)
+	assert.Must(t).Nil(passed.Failure)
+	assert.Must(t).Equal([]junitProperty{{Name: `tag`, Value: `math`}, {Name: `tag`, Value: `unit`}}, passed.Properties.Properties)
+
+	failed := cases[`describe #Add when the value is positive then it reports the change`]
+	assert.Must(t).NotNil(failed.Failure)
+	assert.Must(t).Contain(failed.Failure.Content, `[Equal]`)
+
+	skipped := cases[`describe #Add then it is skipped`]
+	assert.Must(t).NotNil(skipped.Skipped)
+	assert.Must(t).Equal(`not today`, skipped.Skipped.Message)
+
+	pending := cases[`describe #Add then it handles overflow`]
+	assert.Must(t).NotNil(pending.Skipped)
+	assert.Must(t).Equal(`PENDING: TODO`, pending.Skipped.Message)
+
+	flaky := cases[`describe #Add then it is flaky`]
+	assert.Must(t).Nil(flaky.Failure)
+	assert.Must(t).Contain(flaky.Properties.Properties, junitProperty{Name: `attempts`, Value: `3`})
+}
+
+func TestWriteJUnitReport_directoryPath(t *testing.T) {
+	dir := t.TempDir()
+	pkg := &reportedPackage{path: `example.com/foo/bar`}
+	assert.Must(t).Nil(writeJUnitReport(dir, pkg))
+	bs, err := ioutil.ReadFile(filepath.Join(dir, `example.com`, `foo`, `bar.xml`))
+	assert.Must(t).Nil(err)
+	assert.Must(t).Contain(string(bs), `<testsuites name="example.com/foo/bar"`)
+}
+
+func TestJUnitTime(t *testing.T) {
+	assert.Must(t).Equal(`1.500`, junitTime(1500*time.Millisecond))
+}
+
+func TestSpec_junitReport_testingTBIsNotReplaced(t *testing.T) {
+	path := filepath.Join(t.TempDir(), `report.xml`)
+	SetEnv(t, EnvKeyJUnitOutput, path)
+	internal.SetupCacheFlush(t)
+
+	stub := &internal.StubTB{StubName: `TestSubject`}
+	s := NewSpec(stub)
+	var tb testing.TB
+	s.Test(`test`, func(t *T) {
+		tb = t.TB
+		t.Error(`boom`)
+	})
+	stub.Finish()
+
+	assert.Must(t).True(tb == testing.TB(stub))
+	bs, err := ioutil.ReadFile(path)
+	assert.Must(t).Nil(err)
+	var report junitTestSuites
+	assert.Must(t).Nil(xml.Unmarshal(bs, &report))
+	assert.Must(t).Equal(1, report.Failures)
+	assert.Must(t).Contain(report.TestSuites[0].TestCases[0].Failure.Content, `boom`)
+}
+
+func TestSpec_junitReport_propertyRecordsOnlyTheMinimalCounterexample(t *testing.T) {
+	path := filepath.Join(t.TempDir(), `report.xml`)
+	SetEnv(t, EnvKeyJUnitOutput, path)
+	internal.SetupCacheFlush(t)
+
+	stub := &internal.StubTB{StubName: `TestSubject`}
+	s := NewSpec(stub)
+	s.Property(`n`, func(t *T, gen *Gen) {
+		t.Must.True(gen.IntN(100) < 10, `n is too big`)
+	})
+	stub.Finish()
+
+	bs, err := ioutil.ReadFile(path)
+	assert.Must(t).Nil(err)
+	var report junitTestSuites
+	assert.Must(t).Nil(xml.Unmarshal(bs, &report))
+	assert.Must(t).Equal(1, report.Failures)
+	content := report.TestSuites[0].TestCases[0].Failure.Content
+	assert.Must(t).True(strings.HasPrefix(content, `property failed at run #`), content)
+	assert.Must(t).Equal(1, strings.Count(content, `n is too big`), content)
+}
+
+func readJUnitReport(tb testing.TB, path string) junitTestSuites {
+	tb.Helper()
+	bs, err := ioutil.ReadFile(path)
+	assert.Must(tb).Nil(err)
+	var report junitTestSuites
+	assert.Must(tb).Nil(xml.Unmarshal(bs, &report))
+	return report
+}
+
+func TestSpec_junitReport_specSkip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), `report.xml`)
+	SetEnv(t, EnvKeyJUnitOutput, path)
+	internal.SetupCacheFlush(t)
+
+	stub := &internal.StubTB{StubName: `TestSubject`}
+	s := NewSpec(stub)
+	s.Skip(`not implemented yet`)
+	s.Test(`test`, func(t *T) {})
+	stub.Finish()
+
+	report := readJUnitReport(t, path)
+	assert.Must(t).Equal(1, report.Skipped)
+	skipped := report.TestSuites[0].TestCases[0].Skipped
+	assert.Must(t).NotNil(skipped)
+	assert.Must(t).Contain(skipped.Message, `not implemented yet`)
+}
+
+func TestSpec_junitReport_goroutineLeak(t *testing.T) {
+	path := filepath.Join(t.TempDir(), `report.xml`)
+	SetEnv(t, EnvKeyJUnitOutput, path)
+	internal.SetupCacheFlush(t)
+
+	done := make(chan struct{})
+	defer close(done)
+	stub := &internal.StubTB{StubName: `TestSubject`}
+	s := NewSpec(stub, DetectGoroutineLeaks())
+	s.Test(`test`, func(t *T) { go leakingWorker(done) })
+	stub.Finish()
+
+	report := readJUnitReport(t, path)
+	assert.Must(t).Equal(1, report.Failures)
+	assert.Must(t).Contain(report.TestSuites[0].TestCases[0].Failure.Content, `goroutine(s) leaked by the test`)
+}
+
+func TestSpec_junitReport_stateMachine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), `report.xml`)
+	SetEnv(t, EnvKeyJUnitOutput, path)
+	internal.SetupCacheFlush(t)
+
+	stub := &internal.StubTB{StubName: `TestSubject`}
+	s := NewSpec(stub)
+	counter := s.Let(`counter`, func(t *T) interface{} { return new(int) })
+	s.Test(`test`, StateMachine{
+		Commands: []StateMachineCommand{{
+			Name: `Inc`,
+			Run:  func(t *T, gen *Gen) { *counter.Get(t).(*int)++ },
+		}},
+		Invariant: func(t *T) { t.Must.True(*counter.Get(t).(*int) < 3) },
+	}.Check)
+	stub.Finish()
+
+	report := readJUnitReport(t, path)
+	assert.Must(t).Equal(1, report.Failures)
+	assert.Must(t).Contain(report.TestSuites[0].TestCases[0].Failure.Content, `Inc`)
+}
@@ -2,6 +2,8 @@ package testcase
 
 import (
 	"fmt"
+	"regexp"
+	"time"
 )
 
 // Flaky will mark the spec/testCase as unstable.
@@ -28,6 +30,9 @@ import (
 // While this functionality might help in tough times,
 // it is advised to pair the usage with a scheduled monthly CI pipeline job.
 // The Job should check the testing code base for the flaky flag.
+// The tests that passed only after retries are listed at the end of the specification,
+// and with TESTCASE_FLAKY_REPORT, every flaky test execution is recorded into a ledger file,
+// with the number of attempts and the failure messages of the discarded attempts.
 //
 func Flaky(CountOrTimeout interface{}) SpecOption {
 	retry, ok := makeRetry(CountOrTimeout)
@@ -45,8 +50,61 @@ func RetryStrategyForEventually(strategy RetryStrategy) SpecOption {
 	})
 }
 
-//func Timeout(duration time.Duration) SpecOption {}
-//func OrderWith(orderer) SpecOption {}
+// Timeout will limit how long a test case can run, including the execution of its hooks.
+// When the test case runs longer than the provided duration, it will fail,
+// and the failure report will contain the description path of the test,
+// the phase in which the test got stuck (hook, test block or teardown),
+// and the goroutine stacks at the time of the timeout.
+// The test ends at the timeout, while the stuck goroutine is left behind,
+// thus the teardown of a timed out test is not guaranteed to run.
+//
+// Timeout is inherited by the nested contexts and tests,
+// and the closest scope's Timeout overrides the ones defined at the outer scopes.
+// When used together with Flaky, the timeout applies to each retry attempt individually.
+// Timeout has no effect in benchmark mode.
+func Timeout(duration time.Duration) SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.timeout = &duration
+	})
+}
+
+// OrderWith will set the Orderer that arranges the execution order of the test cases
+// in the current Spec and below, overriding the ordering set with the TESTCASE_ORDERING environment variable.
+// The test cases of a testing group are ordered together,
+// thus OrderWith on a Context takes effect when the Context is a testing group as well (e.g.: Describe or Group).
+func OrderWith(o Orderer) SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.orderer = o
+	})
+}
+
+// Focus will mark the spec/testCase as focused.
+// When a specification tree has a focused part, only the focused tests will run,
+// and every other test will be reported as skipped.
+// For more, read the documentation of Spec.Focus.
+func Focus() SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.focused = true
+	})
+}
+
+// Pending will mark the spec/testCase as pending.
+// Pending tests are reported with the given reason, but they are never executed.
+// For more, read the documentation of Spec.Pending.
+func Pending(reason string) SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.pending = &reason
+	})
+}
+
+// PropertyRuns will set how many times the property based tests execute with generated input values
+// in the current Spec and below.
+// For more, read the documentation of Spec.Property.
+func PropertyRuns(n int) SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.propertyRuns = &n
+	})
+}
 
 func SkipBenchmark() SpecOption {
 	return specOptionFunc(func(c *Spec) {
@@ -54,6 +112,76 @@ func SkipBenchmark() SpecOption {
 	})
 }
 
+// ReportAllocs will enable the malloc statistics for the benchmarks of the current Spec and below.
+// It has the same effect as calling testing.B.ReportAllocs in the benchmark.
+func ReportAllocs() SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.reportAllocs = true
+	})
+}
+
+// BenchmarkTolerance will set how many percent a benchmark can be slower than its baseline
+// in the current Spec and below, before it is reported as a regression.
+// By default, the tolerance is 10%.
+// For more, read the documentation of EnvKeyBenchBaseline.
+func BenchmarkTolerance(percent float64) SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.benchmarkTolerance = &percent
+	})
+}
+
+// ParallelBenchmark will execute the benchmarks of the current Spec and below with testing.B.RunParallel.
+// Each goroutine of the benchmark receives its own T, thus the Let variables are initialized per goroutine.
+// The hooks are part of the benchmark timing, since the timer can't be stopped in a parallel benchmark,
+// and T.Measure has no effect.
+// Parallel benchmarks are not compared with the benchmark baseline.
+func ParallelBenchmark() SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.parallelBenchmark = true
+	})
+}
+
+// DetectGoroutineLeaks will check after each test in the current Spec and below,
+// that the goroutines started by the test are finished by the end of the teardown.
+// The goroutines that are still running after a short grace period fail the test,
+// and their stack is printed along with the location where they were created.
+// Goroutine leak detection can be enabled for every test with TESTCASE_GOROUTINE_LEAKS as well.
+//
+// In a Parallel test, only the goroutines started by the test's goroutine
+// (or by a goroutine it started) are checked, since the other tests run concurrently.
+// Goroutine leak detection has no effect in benchmark mode.
+func DetectGoroutineLeaks() SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.detectGoroutineLeaks = true
+	})
+}
+
+// FailureReportValues will include the values of the initialized variables
+// in the failure report of each failed test in the current Spec and below.
+// By default, the failure report only contains where the contexts, the hooks and the variables of the test were declared.
+// Since the variable values are printed, they should not hold secrets when the values are reported.
+// The variable values can be reported for every test with TESTCASE_FAILURE_REPORT_VALUES as well.
+func FailureReportValues() SpecOption {
+	return specOptionFunc(func(s *Spec) {
+		s.reportValues = true
+	})
+}
+
+// IgnoreGoroutines will set regular expression patterns for the goroutine leak detection
+// in the current Spec and below.
+// A goroutine which has a stack that matches any of the patterns is not reported as a leak,
+// which is useful for known background goroutines, like a connection pool's worker.
+// IgnoreGoroutines panics when a pattern is not a valid regular expression.
+func IgnoreGoroutines(stackPatterns ...string) SpecOption {
+	var patterns []*regexp.Regexp
+	for _, pattern := range stackPatterns {
+		patterns = append(patterns, regexp.MustCompile(pattern))
+	}
+	return specOptionFunc(func(s *Spec) {
+		s.ignoredGoroutines = append(s.ignoredGoroutines, patterns...)
+	})
+}
+
 // Group creates a testing group in the specification.
 // During testCase execution, a group will be bundled together,
 // and parallel tests will run concurrently within the the testing group.
@@ -1,39 +1,94 @@
 package testcase
 
 import (
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"io/ioutil"
 	"math/rand"
 	"os"
+	"path/filepath"
+	"sort"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/adamluzsi/testcase/internal"
 )
 
-func newOrderer(tb testing.TB, seed int64) orderer {
+func newOrderer(tb testing.TB, seed int64) Orderer {
 	tb.Helper()
 	switch mod := getGlobalOrderMod(tb); mod {
 	case OrderingAsDefined:
 		return nullOrderer{}
 	case OrderingAsRandom, undefinedOrdering:
 		return randomOrderer{Seed: seed}
+	case OrderingAsReversed:
+		return reverseOrderer{}
+	case OrderingAsSlowestFirst:
+		return SlowestFirstOrderer(getOrderingHistoryPath())
+	case OrderingAsFailedFirst:
+		return FailedFirstOrderer(getOrderingHistoryPath())
 	default:
 		panic(fmt.Sprintf(`unknown ordering mod: %s`, mod))
 	}
 }
 
-type orderer interface {
-	Order(tc []func())
+// Orderer arranges the execution order of the test cases within a testing group.
+// Orderer can be set with the TESTCASE_ORDERING environment variable globally,
+// or with the OrderWith SpecOption for a given Spec or Context.
+type Orderer interface {
+	// Order arranges the received test cases in place.
+	Order(tests []func())
+}
+
+// idOrderer is implemented by the Orderer that needs to know the identity of the test cases,
+// in order to arrange them, like the orderers that use the results of a previous test run.
+type idOrderer interface {
+	orderByID(ids []string, tests []func())
+}
+
+// testObserver is implemented by the Orderer that learns from the test case executions.
+// The observations are kept in memory, and they are saved with flush,
+// once the root Spec and all of its tests are finished.
+type testObserver interface {
+	observe(id string, duration time.Duration, failed bool)
+	flush() error
 }
 
 type testOrderingMod string
 
 const (
-	undefinedOrdering testOrderingMod = ``
-	OrderingAsDefined testOrderingMod = `defined`
-	OrderingAsRandom  testOrderingMod = `random`
+	undefinedOrdering      testOrderingMod = ``
+	OrderingAsDefined      testOrderingMod = `defined`
+	OrderingAsRandom       testOrderingMod = `random`
+	OrderingAsReversed     testOrderingMod = `reversed`
+	OrderingAsSlowestFirst testOrderingMod = `slowest-first`
+	OrderingAsFailedFirst  testOrderingMod = `failed-first`
 )
 
+type testCase struct {
+	id  string
+	run func()
+}
+
+func orderTestCases(o Orderer, tcs []testCase) []func() {
+	var (
+		ids   = make([]string, len(tcs))
+		tests = make([]func(), len(tcs))
+	)
+	for i, tc := range tcs {
+		ids[i] = tc.id
+		tests[i] = tc.run
+	}
+	if o, ok := o.(idOrderer); ok {
+		o.orderByID(ids, tests)
+		return tests
+	}
+	o.Order(tests)
+	return tests
+}
+
 //------------------------------------------------- order as defined -------------------------------------------------//
 
 type nullOrderer struct{}
@@ -60,6 +115,190 @@ func (o randomOrderer) swapFunc(tests []func()) func(i int, j int) {
 	}
 }
 
+//------------------------------------------------- order reversed --------------------------------------------------//
+
+// ReverseOrderer will execute the test cases in the reverse order of their definition.
+// It is useful to reveal test cases that depend on the side effects of the previously defined ones.
+func ReverseOrderer() Orderer {
+	return reverseOrderer{}
+}
+
+type reverseOrderer struct{}
+
+func (o reverseOrderer) Order(tests []func()) {
+	for i, j := 0, len(tests)-1; i < j; i, j = i+1, j-1 {
+		tests[i], tests[j] = tests[j], tests[i]
+	}
+}
+
+//-------------------------------------------- order by previous test run --------------------------------------------//
+
+// SlowestFirstOrderer will execute first the test cases which were the slowest during the previous test run.
+// Test cases without a recorded duration are considered new, and they are executed before the rest.
+// The test durations are recorded into the file at historyPath, which is used for the next test run.
+func SlowestFirstOrderer(historyPath string) Orderer {
+	return historyOrderer{
+		history: getOrderingHistory(historyPath),
+		less: func(a, b orderingHistoryRecord, aOK, bOK bool) bool {
+			if aOK != bOK {
+				return !aOK
+			}
+			return a.Duration > b.Duration
+		},
+	}
+}
+
+// FailedFirstOrderer will execute first the test cases which failed during the previous test run,
+// then continue with the rest of the test cases in the order of their definition.
+// The test results are recorded into the file at historyPath, which is used for the next test run.
+func FailedFirstOrderer(historyPath string) Orderer {
+	return historyOrderer{
+		history: getOrderingHistory(historyPath),
+		less: func(a, b orderingHistoryRecord, aOK, bOK bool) bool {
+			return a.Failed && !b.Failed
+		},
+	}
+}
+
+type historyOrderer struct {
+	history *orderingHistory
+	less    func(a, b orderingHistoryRecord, aOK, bOK bool) bool
+}
+
+// Order without the identity of the test cases can't use the history,
+// thus the tests are kept in their defined order.
+func (o historyOrderer) Order([]func()) {}
+
+func (o historyOrderer) orderByID(ids []string, tests []func()) {
+	var (
+		records = make([]orderingHistoryRecord, len(ids))
+		known   = make([]bool, len(ids))
+		indexes = make([]int, len(ids))
+	)
+	for i, id := range ids {
+		records[i], known[i] = o.history.lookup(id)
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		a, b := indexes[i], indexes[j]
+		return o.less(records[a], records[b], known[a], known[b])
+	})
+	var (
+		ogIDs   = append([]string{}, ids...)
+		ogTests = append([]func(){}, tests...)
+	)
+	for i, index := range indexes {
+		ids[i] = ogIDs[index]
+		tests[i] = ogTests[index]
+	}
+}
+
+func (o historyOrderer) observe(id string, duration time.Duration, failed bool) {
+	o.history.record(id, orderingHistoryRecord{Duration: duration, Failed: failed})
+}
+
+func (o historyOrderer) flush() error {
+	return o.history.flush()
+}
+
+type orderingHistoryRecord struct {
+	Duration time.Duration `json:"duration"`
+	Failed   bool          `json:"failed"`
+}
+
+// orderingHistory holds the test results of the previous test run,
+// and records the results of the current one into the same file.
+type orderingHistory struct {
+	path string
+
+	mutex    sync.Mutex
+	previous map[string]orderingHistoryRecord
+	current  map[string]orderingHistoryRecord
+	// dirty tells if the current records changed since the last save.
+	dirty bool
+}
+
+func (h *orderingHistory) lookup(id string) (orderingHistoryRecord, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	r, ok := h.previous[id]
+	return r, ok
+}
+
+func (h *orderingHistory) record(id string, r orderingHistoryRecord) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.current[id] = r
+	h.dirty = true
+}
+
+// flush saves the records when they changed since the last save.
+func (h *orderingHistory) flush() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if !h.dirty {
+		return nil
+	}
+	if err := h.save(); err != nil {
+		return err
+	}
+	h.dirty = false
+	return nil
+}
+
+func (h *orderingHistory) load() {
+	h.previous = make(map[string]orderingHistoryRecord)
+	h.current = make(map[string]orderingHistoryRecord)
+	bs, err := ioutil.ReadFile(h.path)
+	if err != nil {
+		return
+	}
+	_ = json.Unmarshal(bs, &h.previous)
+}
+
+func (h *orderingHistory) save() error {
+	records := make(map[string]orderingHistoryRecord, len(h.previous)+len(h.current))
+	// records of test cases which didn't run this time are kept for the next test run.
+	for id, r := range h.previous {
+		records[id] = r
+	}
+	for id, r := range h.current {
+		records[id] = r
+	}
+	bs, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(h.path, bs, 0644)
+}
+
+var (
+	orderingHistories      = make(map[string]*orderingHistory)
+	orderingHistoriesMutex sync.Mutex
+	_                      = internal.RegisterCacheFlush(func() {
+		orderingHistoriesMutex.Lock()
+		defer orderingHistoriesMutex.Unlock()
+		orderingHistories = make(map[string]*orderingHistory)
+	})
+)
+
+// getOrderingHistory ensures that specs which use the same history file share the records,
+// so they don't overwrite each other's test results.
+func getOrderingHistory(path string) *orderingHistory {
+	orderingHistoriesMutex.Lock()
+	defer orderingHistoriesMutex.Unlock()
+	if h, ok := orderingHistories[path]; ok {
+		return h
+	}
+	h := &orderingHistory{path: path}
+	h.load()
+	orderingHistories[path] = h
+	return h
+}
+
 //---------------------------------------------- Global Test ordering Mod ----------------------------------------------//
 
 var (
@@ -87,7 +326,48 @@ func getOrderingModFromENV() testOrderingMod {
 		return OrderingAsDefined
 	case OrderingAsRandom:
 		return OrderingAsRandom
+	case OrderingAsReversed:
+		return OrderingAsReversed
+	case OrderingAsSlowestFirst:
+		return OrderingAsSlowestFirst
+	case OrderingAsFailedFirst:
+		return OrderingAsFailedFirst
 	default:
 		panic(fmt.Sprintf(`unknown testCase ordering/arrange mod: %s`, mod))
 	}
 }
+
+func getOrderingHistoryPath() string {
+	if path, ok := os.LookupEnv(EnvKeyOrderingHistory); ok && path != `` {
+		return path
+	}
+	return defaultOrderingHistoryPath()
+}
+
+// defaultOrderingHistoryPath is a file in the user's cache directory, which is unique to the working directory,
+// so the packages don't share their history, and the package directory is left untouched.
+func defaultOrderingHistoryPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	wd, _ := os.Getwd()
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(wd))
+	return filepath.Join(dir, `testcase`, `ordering-history`, fmt.Sprintf(`%x.json`, h.Sum64()))
+}
+
+// saveOrderingHistory saves the test results that the history based orderers of the specification observed.
+// It is executed once, in the cleanup of the root Spec, when all of its tests are finished, including the Parallel ones.
+func (spec *Spec) saveOrderingHistory() {
+	spec.testingTB.Helper()
+	spec.acceptVisitor(visitorFunc(func(s *Spec) {
+		o, ok := s.orderer.(testObserver)
+		if !ok {
+			return
+		}
+		if err := o.flush(); err != nil {
+			spec.testingTB.Errorf(`unable to save the ordering history: %s`, err.Error())
+		}
+	}))
+}
@@ -1,6 +1,11 @@
 package testcase
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,8 +16,8 @@ import (
 
 var ord = Var{Name: `orderer`}
 
-func ordGet(t *T) orderer {
-	return ord.Get(t).(orderer)
+func ordGet(t *T) Orderer {
+	return ord.Get(t).(Orderer)
 }
 
 func cpyOrdOut(src []int) []int {
@@ -169,6 +174,126 @@ func TestRandomOrderer_Order(t *testing.T) {
 	})
 }
 
+func TestReverseOrderer_Order(t *testing.T) {
+	s := NewSpec(t)
+	s.NoSideEffect()
+
+	ord.Let(s, func(t *T) interface{} {
+		return ReverseOrderer()
+	})
+
+	s.Describe(`Order`, func(s *Spec) {
+		subject := func(t *T, input []func()) {
+			ordGet(t).Order(input)
+		}
+
+		s.Test(`.Order should reverse the order of the id list`, func(t *T) {
+			out := &[]int{}
+			in := genOrdInput(out)
+			before := runOrdInput(in, out)
+			subject(t, in)
+			after := runOrdInput(in, out)
+			t.Must.Equal(len(before), len(after))
+			for i := range before {
+				t.Must.Equal(before[i], after[len(after)-1-i])
+			}
+		})
+	})
+}
+
+func writeOrderingHistory(tb testing.TB, records map[string]orderingHistoryRecord) string {
+	internal.SetupCacheFlush(tb)
+	path := filepath.Join(tb.TempDir(), `history.json`)
+	bs, err := json.Marshal(records)
+	assert.Must(tb).Nil(err)
+	assert.Must(tb).Nil(ioutil.WriteFile(path, bs, 0644))
+	return path
+}
+
+func orderTestCasesByID(o Orderer, ids []string) []string {
+	var (
+		out []string
+		tcs []testCase
+	)
+	for _, id := range ids {
+		id := id
+		tcs = append(tcs, testCase{id: id, run: func() { out = append(out, id) }})
+	}
+	for _, test := range orderTestCases(o, tcs) {
+		test()
+	}
+	return out
+}
+
+func TestSlowestFirstOrderer(t *testing.T) {
+	s := NewSpec(t)
+
+	s.Describe(`Order`, func(s *Spec) {
+		s.Test(`slowest test cases from the previous run are ordered first, and new test cases before the known ones`, func(t *T) {
+			path := writeOrderingHistory(t, map[string]orderingHistoryRecord{
+				`fast`:   {Duration: time.Millisecond},
+				`slow`:   {Duration: time.Second},
+				`medium`: {Duration: 100 * time.Millisecond},
+			})
+			out := orderTestCasesByID(SlowestFirstOrderer(path), []string{`fast`, `slow`, `new`, `medium`})
+			t.Must.Equal([]string{`new`, `slow`, `medium`, `fast`}, out)
+		})
+
+		s.Test(`without test case identity the defined order is kept`, func(t *T) {
+			out := &[]int{}
+			in := genOrdInput(out)
+			before := runOrdInput(in, out)
+			SlowestFirstOrderer(writeOrderingHistory(t, nil)).Order(in)
+			t.Must.Equal(before, runOrdInput(in, out))
+		})
+	})
+
+	s.Describe(`observe`, func(s *Spec) {
+		s.Test(`the recorded durations are used in the next test run`, func(t *T) {
+			path := writeOrderingHistory(t, nil)
+			o := SlowestFirstOrderer(path).(testObserver)
+			o.observe(`fast`, time.Millisecond, false)
+			o.observe(`slow`, time.Second, false)
+			t.Must.Nil(o.flush())
+
+			internal.CacheFlush() // next test run
+			out := orderTestCasesByID(SlowestFirstOrderer(path), []string{`fast`, `slow`})
+			t.Must.Equal([]string{`slow`, `fast`}, out)
+		})
+	})
+}
+
+func TestFailedFirstOrderer(t *testing.T) {
+	s := NewSpec(t)
+
+	s.Describe(`Order`, func(s *Spec) {
+		s.Test(`failed test cases from the previous run are ordered first, the rest keep the defined order`, func(t *T) {
+			path := writeOrderingHistory(t, map[string]orderingHistoryRecord{
+				`a`: {Failed: false},
+				`b`: {Failed: true},
+				`d`: {Failed: true},
+			})
+			out := orderTestCasesByID(FailedFirstOrderer(path), []string{`a`, `b`, `c`, `d`, `e`})
+			t.Must.Equal([]string{`b`, `d`, `a`, `c`, `e`}, out)
+		})
+	})
+
+	s.Describe(`observe`, func(s *Spec) {
+		s.Test(`records of the test cases that didn't run are kept for the next run`, func(t *T) {
+			path := writeOrderingHistory(t, map[string]orderingHistoryRecord{
+				`a`: {Failed: true},
+			})
+			o := FailedFirstOrderer(path).(testObserver)
+			o.observe(`b`, time.Second, true)
+			t.Must.Nil(o.flush())
+
+			internal.CacheFlush() // next test run
+			out := orderTestCasesByID(FailedFirstOrderer(path), []string{`c`, `b`, `a`})
+			t.Must.Equal([]string{`b`, `a`, `c`}, out)
+		})
+	})
+}
+
 func TestNewOrderer(t *testing.T) {
 	s := NewSpec(t)
 
@@ -176,7 +301,7 @@ func TestNewOrderer(t *testing.T) {
 		return int64(t.Random.Int())
 	})
 	seedGet := func(t *T) int64 { return seed.Get(t).(int64) }
-	subject := func(t *T) orderer {
+	subject := func(t *T) Orderer {
 		return newOrderer(t, seedGet(t))
 	}
 
@@ -216,4 +341,66 @@ func TestNewOrderer(t *testing.T) {
 			t.Must.True(ok)
 		})
 	})
+
+	s.When(`mod set to reversed ordering`, func(s *Spec) {
+		s.Before(func(t *T) {
+			SetEnv(t, EnvKeyOrdering, string(OrderingAsReversed))
+		})
+
+		s.Then(`reverse orderer provided`, func(t *T) {
+			_, ok := subject(t).(reverseOrderer)
+			t.Must.True(ok)
+		})
+	})
+
+	s.When(`mod set to slowest first`, func(s *Spec) {
+		s.Before(func(t *T) {
+			SetEnv(t, EnvKeyOrdering, string(OrderingAsSlowestFirst))
+			SetEnv(t, EnvKeyOrderingHistory, filepath.Join(t.TempDir(), `history.json`))
+		})
+
+		s.Then(`history based orderer provided`, func(t *T) {
+			o, ok := subject(t).(historyOrderer)
+			t.Must.True(ok)
+			t.Must.Equal(os.Getenv(EnvKeyOrderingHistory), o.history.path)
+		})
+	})
+
+	s.When(`mod set to failed first`, func(s *Spec) {
+		s.Before(func(t *T) {
+			SetEnv(t, EnvKeyOrdering, string(OrderingAsFailedFirst))
+			UnsetEnv(t, EnvKeyOrderingHistory)
+		})
+
+		s.Then(`history based orderer provided with the default history path`, func(t *T) {
+			o, ok := subject(t).(historyOrderer)
+			t.Must.True(ok)
+			t.Must.Equal(defaultOrderingHistoryPath(), o.history.path)
+			wd, err := os.Getwd()
+			t.Must.Nil(err)
+			t.Must.True(!strings.HasPrefix(o.history.path, wd))
+		})
+	})
+}
+
+func TestSpec_orderingHistory(t *testing.T) {
+	internal.SetupCacheFlush(t)
+	path := filepath.Join(t.TempDir(), `history.json`)
+	stub := &internal.StubTB{StubName: `TestSubject`}
+	s := NewSpec(stub, OrderWith(FailedFirstOrderer(path)))
+	s.Test(`a`, func(t *T) {})
+	s.Test(`b`, func(t *T) { t.Fail() })
+	s.Finish()
+
+	_, err := os.Stat(path)
+	assert.Must(t).True(os.IsNotExist(err), `the history is expected to be saved by the cleanup of the root spec`)
+
+	stub.Finish()
+	bs, err := ioutil.ReadFile(path)
+	assert.Must(t).Nil(err)
+	var records map[string]orderingHistoryRecord
+	assert.Must(t).Nil(json.Unmarshal(bs, &records))
+	assert.Must(t).Equal(2, len(records))
+	assert.Must(t).True(!records[`TestSubject/a`].Failed)
+	assert.Must(t).True(records[`TestSubject/b`].Failed)
 }
@@ -0,0 +1,102 @@
+package testcase
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Pending will mark the current Spec and the specs below as pending.
+// Pending tests are reported with their reason, but they are never executed,
+// thus neither the Let variables nor the hooks will be evaluated for them.
+// This allows writing the outline of a specification first, and filling the tests later.
+//
+// The number of the pending tests is summarised at the end of the specification execution.
+// If you wish to mark only a certain test or context pending, use the Pending SpecOption instead.
+func (spec *Spec) Pending(reason string) {
+	spec.testingTB.Helper()
+	if spec.immutable {
+		spec.testingTB.Fatalf(warnEventOnImmutableFormat, `Pending`)
+	}
+	Pending(reason).setup(spec)
+}
+
+// Todo is a placeholder for a test that is yet to be written.
+// It is reported as a pending test.
+func (spec *Spec) Todo(desc string) {
+	spec.testingTB.Helper()
+	spec.Then(desc, func(t *T) {}, Pending(`TODO`))
+}
+
+const pendingSkipFormat = `PENDING: %s`
+
+func (spec *Spec) lookupPending() (string, bool) {
+	spec.testingTB.Helper()
+	specs := spec.list()
+	for i := len(specs) - 1; 0 <= i; i-- {
+		if specs[i].pending != nil {
+			return *specs[i].pending, true
+		}
+	}
+	return ``, false
+}
+
+func (spec *Spec) skipPending(tb interface{ Skipf(string, ...interface{}) }) {
+	spec.testingTB.Helper()
+	reason, ok := spec.lookupPending()
+	if !ok {
+		return
+	}
+	spec.result.markPending(reason)
+	spec.list()[0].pendingTests.add(spec.descriptionPathLine(), reason)
+	tb.Skipf(pendingSkipFormat, reason)
+}
+
+func (spec *Spec) printPendingSummary() {
+	spec.testingTB.Helper()
+	if spec.parent != nil {
+		return
+	}
+	tests := spec.pendingTests.flush()
+	if len(tests) == 0 {
+		return
+	}
+	lines := []interface{}{fmt.Sprintf("%d pending test(s):\n", len(tests))}
+	for _, pt := range tests {
+		lines = append(lines, fmt.Sprintf("  %s: %s\n", pt.desc, pt.reason))
+	}
+	log(spec.testingTB, lines...)
+}
+
+// descriptionPathLine returns the description of the spec tree in a single line.
+func (spec *Spec) descriptionPathLine() string {
+	var parts []string
+	for _, line := range spec.descriptionLines() {
+		parts = append(parts, strings.TrimSpace(line))
+	}
+	return strings.Join(parts, ` `)
+}
+
+type pendingTests struct {
+	mutex sync.Mutex
+	tests []pendingTest
+}
+
+type pendingTest struct {
+	desc   string
+	reason string
+}
+
+func (pts *pendingTests) add(desc, reason string) {
+	pts.mutex.Lock()
+	defer pts.mutex.Unlock()
+	pts.tests = append(pts.tests, pendingTest{desc: desc, reason: reason})
+}
+
+func (pts *pendingTests) flush() []pendingTest {
+	pts.mutex.Lock()
+	defer pts.mutex.Unlock()
+	tests := pts.tests
+	pts.tests = nil
+	return tests
+}
@@ -0,0 +1,321 @@
+package testcase
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/adamluzsi/testcase/fixtures"
+	"github.com/adamluzsi/testcase/internal"
+	"github.com/adamluzsi/testcase/random"
+)
+
+// Property creates a property based test, where the test block is executed multiple times with generated input values.
+// The values are generated with the received Gen, which is seeded from the Spec seed,
+// thus a failing property can be replayed by providing the same TESTCASE_SEED.
+//
+// When the property fails, the failing input is shrunk to a minimal counterexample:
+// integers shrink toward zero, strings and slices toward shorter ones, and struct fields toward their zero value.
+// The failure report contains the minimal failing input, and the assertion failures of the minimal case.
+//
+// Each run is an individual test execution, thus the Let variables and the hooks are evaluated for each run.
+// The number of runs can be configured with the PropertyRuns SpecOption.
+func (spec *Spec) Property(desc string, blk func(t *T, gen *Gen), opts ...SpecOption) {
+	spec.testingTB.Helper()
+	s := spec.newSubSpec(desc, opts...)
+	s.property = true
+	s.run(func(t *T) { blk(t, t.gen) })
+}
+
+const defaultPropertyRuns = 100
+
+func (spec *Spec) lookupPropertyRuns() int {
+	spec.testingTB.Helper()
+	specs := spec.list()
+	for i := len(specs) - 1; 0 <= i; i-- {
+		if specs[i].propertyRuns != nil {
+			return *specs[i].propertyRuns
+		}
+	}
+	return defaultPropertyRuns
+}
+
+// maxPropertyShrinks limits the number of test executions during shrinking,
+// so a slow property can't make the test run forever.
+const maxPropertyShrinks = 1000
+
+// checkProperty executes the property runs, and shrinks the input of the first failing run.
+// Only the report and the failures of the minimal counterexample are recorded into the test result,
+// the runs and the shrinking attempts record into a throwaway result.
+func (spec *Spec) checkProperty(tb testing.TB, test func(testing.TB, *Gen, *testResult)) {
+	spec.testingTB.Helper()
+	tb.Helper()
+	seeds := rand.New(rand.NewSource(spec.testSeed()))
+	runs := spec.lookupPropertyRuns()
+	for run := 1; run <= runs; run++ {
+		gen := newGen(rand.NewSource(seeds.Int63()), nil)
+		if !spec.tryProperty(tb, test, gen) {
+			continue
+		}
+
+		shrinker := propertyShrinker{
+			choices: gen.source.choices,
+			fails: func(choices []int64) ([]int64, bool) {
+				gen := newGen(nil, choices)
+				return gen.source.choices, spec.tryProperty(tb, test, gen)
+			},
+		}
+		minimal := newGen(nil, shrinker.shrink())
+		rtb := &internal.RecorderTB{TB: tb}
+		result := newTestResult()
+		internal.RecoverExceptGoexit(func() { test(rtb, minimal, result) })
+		report := spec.propertyReport(run, shrinker.steps, minimal.getValues())
+		spec.result.addFailure(report)
+		for _, failure := range result.getFailures() {
+			spec.result.addFailure(failure)
+		}
+		tb.Error(report)
+		rtb.Forward()
+		return
+	}
+}
+
+func (spec *Spec) tryProperty(tb testing.TB, test func(testing.TB, *Gen, *testResult), gen *Gen) (failed bool) {
+	spec.testingTB.Helper()
+	tb.Helper()
+	rtb := &internal.RecorderTB{TB: tb}
+	defer rtb.CleanupNow()
+	internal.RecoverExceptGoexit(func() { test(rtb, gen, newTestResult()) })
+	return rtb.IsFailed
+}
+
+func (spec *Spec) propertyReport(run, steps int, values []string) string {
+	var msg strings.Builder
+	_, _ = fmt.Fprintf(&msg, "property failed at run #%d\n", run)
+	if len(values) == 0 {
+		msg.WriteString("\nthe property failed without generated input\n")
+	} else {
+		_, _ = fmt.Fprintf(&msg, "\nminimal failing input (shrunk in %d steps):\n", steps)
+		for _, v := range values {
+			_, _ = fmt.Fprintf(&msg, "\t%s\n", v)
+		}
+	}
+	_, _ = fmt.Fprintf(&msg, "\nreplay with %s=%d", EnvKeySeed, spec.seed)
+	return msg.String()
+}
+
+//----------------------------------------------------- Generator -----------------------------------------------------//
+
+// Gen generates the input values of a property based test.
+// Every generated value is recorded, so the failing input can be shrunk and reported.
+//
+// Gen is built on random.Random and fixtures.Factory,
+// thus with Make, any type that fixtures.Factory supports can be generated.
+type Gen struct {
+	source  *genSource
+	random  *random.Random
+	factory *fixtures.Factory
+
+	mutex  sync.Mutex
+	values []string
+}
+
+func newGen(src rand.Source, choices []int64) *Gen {
+	source := &genSource{rnd: src, prefix: choices}
+	rnd := random.New(source)
+	return &Gen{
+		source:  source,
+		random:  rnd,
+		factory: &fixtures.Factory{Random: rnd},
+	}
+}
+
+// Int returns a pseudo-random int, which can be negative as well.
+func (g *Gen) Int() int {
+	n := g.random.Int()
+	if g.random.IntN(2) == 1 {
+		n = -n
+	}
+	g.record(`Int`, n)
+	return n
+}
+
+// IntN returns a non-negative pseudo-random int in [0,n).
+func (g *Gen) IntN(n int) int {
+	v := g.random.IntN(n)
+	g.record(`IntN`, v)
+	return v
+}
+
+// IntBetween returns a pseudo-random int in [min,max].
+func (g *Gen) IntBetween(min, max int) int {
+	v := g.random.IntBetween(min, max)
+	g.record(`IntBetween`, v)
+	return v
+}
+
+// Float64 returns a pseudo-random float64 in [0.0,1.0).
+func (g *Gen) Float64() float64 {
+	v := g.random.Float64()
+	g.record(`Float64`, v)
+	return v
+}
+
+// Bool returns a pseudo-random bool.
+func (g *Gen) Bool() bool {
+	v := g.random.IntN(2) == 1
+	g.record(`Bool`, v)
+	return v
+}
+
+// String returns a pseudo-random string, which might be empty.
+func (g *Gen) String() string {
+	v := g.random.StringN(g.random.IntN(43))
+	g.record(`String`, v)
+	return v
+}
+
+// StringN returns a pseudo-random string with the given length.
+func (g *Gen) StringN(length int) string {
+	v := g.random.StringN(length)
+	g.record(`StringN`, v)
+	return v
+}
+
+// ElementFromSlice returns a pseudo-randomly selected element of the slice.
+func (g *Gen) ElementFromSlice(slice interface{}) interface{} {
+	v := g.random.ElementFromSlice(slice)
+	g.record(`ElementFromSlice`, v)
+	return v
+}
+
+// Make generates a value with the type of T using fixtures.Factory.
+func (g *Gen) Make(T interface{}) interface{} {
+	v := g.factory.Fixture(T, context.Background())
+	g.record(fmt.Sprintf(`Make(%T)`, T), v)
+	return v
+}
+
+func (g *Gen) record(name string, value interface{}) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.values = append(g.values, fmt.Sprintf(`%s: %#v`, name, value))
+}
+
+func (g *Gen) getValues() []string {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return append([]string{}, g.values...)
+}
+
+// genSource is a rand.Source that records the random choices it made.
+// When it is created with a prefix, it replays the prefix choices first,
+// and once they are exhausted, it either continues with rnd, or with zeros when rnd is absent.
+type genSource struct {
+	rnd     rand.Source
+	prefix  []int64
+	choices []int64
+}
+
+func (s *genSource) Int63() int64 {
+	var v int64
+	switch i := len(s.choices); {
+	case i < len(s.prefix):
+		v = s.prefix[i]
+	case s.rnd != nil:
+		v = s.rnd.Int63()
+	}
+	s.choices = append(s.choices, v)
+	return v
+}
+
+func (s *genSource) Seed(int64) {}
+
+//----------------------------------------------------- Shrinking -----------------------------------------------------//
+
+// propertyShrinker looks for a simpler list of random choices that still fails the property.
+// Since every generated value is derived from the random choices,
+// removing choices makes strings and slices shorter, and smaller choices make the generated values smaller.
+type propertyShrinker struct {
+	choices []int64
+	fails   func(choices []int64) (used []int64, failed bool)
+	steps   int
+	tries   int
+}
+
+func (s *propertyShrinker) shrink() []int64 {
+	for s.tries < maxPropertyShrinks {
+		if !s.deleteChunks() && !s.minimiseChoices() {
+			break
+		}
+	}
+	return s.choices
+}
+
+func (s *propertyShrinker) deleteChunks() (improved bool) {
+	for _, size := range []int{8, 4, 2, 1} {
+		for i := len(s.choices) - size; 0 <= i; i-- {
+			if len(s.choices) < i+size {
+				continue
+			}
+			candidate := append(append([]int64{}, s.choices[:i]...), s.choices[i+size:]...)
+			if s.try(candidate) {
+				improved = true
+			}
+		}
+	}
+	return improved
+}
+
+func (s *propertyShrinker) minimiseChoices() (improved bool) {
+	for i := 0; i < len(s.choices); i++ {
+		lo, hi := int64(0), s.choices[i]
+		for lo < hi {
+			mid := lo + (hi-lo)/2
+			candidate := append([]int64{}, s.choices...)
+			candidate[i] = mid
+			if s.try(candidate) {
+				improved = true
+				if len(s.choices) <= i {
+					return improved
+				}
+				hi = s.choices[i]
+			} else {
+				lo = mid + 1
+			}
+		}
+	}
+	return improved
+}
+
+// try accepts the candidate when it still fails the property and it is simpler than the current choices.
+func (s *propertyShrinker) try(candidate []int64) bool {
+	if maxPropertyShrinks <= s.tries {
+		return false
+	}
+	s.tries++
+	used, failed := s.fails(candidate)
+	if !failed || !isSimplerChoices(used, s.choices) {
+		return false
+	}
+	s.choices = used
+	s.steps++
+	return true
+}
+
+// isSimplerChoices compares the choices in shortlex order,
+// which guarantees that the shrinking always makes progress and eventually terminates.
+func isSimplerChoices(a, b []int64) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package testcase
+
+import (
+	"sync"
+
+	"github.com/adamluzsi/testcase/internal"
+)
+
+// reportedPackage collects the root specifications of a package,
+// so reports can be made about the test run of the whole package.
+type reportedPackage struct {
+	path string
+
+	mutex sync.Mutex
+	roots []reportedSpec
+}
+
+type reportedSpec struct {
+	name string
+	spec *Spec
+}
+
+var (
+	reportedPackages      = make(map[string]*reportedPackage)
+	reportedPackagesMutex sync.Mutex
+	_                     = internal.RegisterCacheFlush(func() {
+		reportedPackagesMutex.Lock()
+		defer reportedPackagesMutex.Unlock()
+		reportedPackages = make(map[string]*reportedPackage)
+	})
+)
+
+func isReportingEnabled() bool {
+	_, docEnabled := getDocOutputDir()
+	_, junitEnabled := getJUnitOutputPath()
+	return docEnabled || junitEnabled
+}
+
+func (spec *Spec) registerReports() {
+	spec.testingTB.Helper()
+	if !isReportingEnabled() {
+		return
+	}
+	path := spec.pkgPath
+	reportedPackagesMutex.Lock()
+	pkg, ok := reportedPackages[path]
+	if !ok {
+		pkg = &reportedPackage{path: path}
+		reportedPackages[path] = pkg
+	}
+	reportedPackagesMutex.Unlock()
+
+	pkg.mutex.Lock()
+	defer pkg.mutex.Unlock()
+	pkg.roots = append(pkg.roots, reportedSpec{name: spec.testingTB.Name(), spec: spec})
+	spec.reports = pkg
+}
+
+// writeReports writes every enabled report about the package of the specification.
+// The reports are rewritten at the end of each root specification,
+// since there is no hook to know when the last test of the package finished.
+func (spec *Spec) writeReports() {
+	spec.testingTB.Helper()
+	if spec.reports == nil {
+		return
+	}
+	pkg := spec.reports
+	pkg.mutex.Lock()
+	defer pkg.mutex.Unlock()
+
+	if dir, ok := getDocOutputDir(); ok {
+		if err := writeDocumentation(dir, pkg); err != nil {
+			spec.testingTB.Errorf(`unable to write the documentation: %s`, err.Error())
+		}
+	}
+	if path, ok := getJUnitOutputPath(); ok {
+		if err := writeJUnitReport(path, pkg); err != nil {
+			spec.testingTB.Errorf(`unable to write the JUnit report: %s`, err.Error())
+		}
+	}
+}
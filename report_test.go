@@ -0,0 +1,27 @@
+package testcase
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/adamluzsi/testcase/internal"
+)
+
+// stubRunner is a TBRunner where each sub test has its own StubTB,
+// so the test results are isolated just like with testing.T sub tests.
+type stubRunner struct {
+	*internal.StubTB
+}
+
+func (r stubRunner) Run(name string, blk func(testing.TB)) bool {
+	sub := &internal.StubTB{StubName: r.Name() + `/` + name}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer sub.Finish()
+		blk(stubRunner{StubTB: sub})
+	}()
+	wg.Wait()
+	return !sub.IsFailed
+}
@@ -0,0 +1,159 @@
+package testcase
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testStatus string
+
+const (
+	testStatusNotRun  testStatus = `not run`
+	testStatusPassed  testStatus = `passed`
+	testStatusFailed  testStatus = `failed`
+	testStatusSkipped testStatus = `skipped`
+	testStatusPending testStatus = `pending`
+)
+
+// testResult holds the outcome of a test case execution,
+// which is used by the reports that are made about the specification.
+type testResult struct {
+	mutex    sync.RWMutex
+	status   testStatus
+	reason   string
+	duration time.Duration
+	failures []string
+	// attempts are the results of the attempts of a test marked with Flaky.
+	attempts []*testResult
+}
+
+func newTestResult() *testResult {
+	return &testResult{status: testStatusNotRun}
+}
+
+func (r *testResult) markPending(reason string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.status = testStatusPending
+	r.reason = reason
+}
+
+// record is expected to be deferred at the beginning of the test execution,
+// so it can observe the outcome of the test even after a FailNow or SkipNow.
+func (r *testResult) record(tb testing.TB, begin time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.duration = time.Since(begin)
+	switch {
+	case r.status == testStatusPending:
+	case tb.Failed():
+		r.status = testStatusFailed
+	case tb.Skipped():
+		r.status = testStatusSkipped
+	default:
+		r.status = testStatusPassed
+	}
+}
+
+func (r *testResult) get() (status testStatus, reason string, duration time.Duration) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.status, r.reason, r.duration
+}
+
+func (r *testResult) addFailure(msg string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.failures = append(r.failures, msg)
+}
+
+func (r *testResult) getFailures() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return append([]string{}, r.failures...)
+}
+
+func (r *testResult) setSkipReason(reason string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.status != testStatusPending {
+		r.reason = reason
+	}
+}
+
+// newAttempt records a new attempt of the test, and returns the result of the attempt.
+func (r *testResult) newAttempt() *testResult {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	attempt := newTestResult()
+	r.attempts = append(r.attempts, attempt)
+	return attempt
+}
+
+func (r *testResult) getAttempts() []*testResult {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return append([]*testResult{}, r.attempts...)
+}
+
+// Error is equivalent to Log followed by Fail,
+// and the message is recorded as a failure for the reports about the test.
+func (t *T) Error(args ...interface{}) {
+	t.TB.Helper()
+	t.recordFailure(fmt.Sprint(args...))
+	t.TB.Error(args...)
+}
+
+// Errorf is equivalent to Logf followed by Fail,
+// and the message is recorded as a failure for the reports about the test.
+func (t *T) Errorf(format string, args ...interface{}) {
+	t.TB.Helper()
+	t.recordFailure(fmt.Sprintf(format, args...))
+	t.TB.Errorf(format, args...)
+}
+
+// Fatal is equivalent to Log followed by FailNow,
+// and the message is recorded as a failure for the reports about the test.
+func (t *T) Fatal(args ...interface{}) {
+	t.TB.Helper()
+	t.recordFailure(fmt.Sprint(args...))
+	t.TB.Fatal(args...)
+}
+
+// Fatalf is equivalent to Logf followed by FailNow,
+// and the message is recorded as a failure for the reports about the test.
+func (t *T) Fatalf(format string, args ...interface{}) {
+	t.TB.Helper()
+	t.recordFailure(fmt.Sprintf(format, args...))
+	t.TB.Fatalf(format, args...)
+}
+
+// Skip is equivalent to Log followed by SkipNow,
+// and the message is recorded as the skip reason for the reports about the test.
+func (t *T) Skip(args ...interface{}) {
+	t.TB.Helper()
+	t.recordSkipReason(fmt.Sprint(args...))
+	t.TB.Skip(args...)
+}
+
+// Skipf is equivalent to Logf followed by SkipNow,
+// and the message is recorded as the skip reason for the reports about the test.
+func (t *T) Skipf(format string, args ...interface{}) {
+	t.TB.Helper()
+	t.recordSkipReason(fmt.Sprintf(format, args...))
+	t.TB.Skipf(format, args...)
+}
+
+func (t *T) recordFailure(msg string) {
+	for _, r := range t.results {
+		r.addFailure(msg)
+	}
+}
+
+func (t *T) recordSkipReason(reason string) {
+	for _, r := range t.results {
+		r.setSkipReason(reason)
+	}
+}
@@ -0,0 +1,50 @@
+package testcase
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// testSeed derives the seed of a test from the Spec seed and the test's id,
+// which is made of the name of the root Spec's testing.TB and the description path of the test.
+// This makes the random values of the tests independent from each other,
+// even between tests with the same description under different Test functions,
+// while a test still gets the same values for the same TESTCASE_SEED,
+// regardless of which other tests are executed in the same run.
+func (spec *Spec) testSeed() int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(spec.id))
+	return spec.seed ^ int64(h.Sum64())
+}
+
+func (spec *Spec) registerPackagePath() {
+	spec.pkgPath = callerPackagePath()
+}
+
+// logReproduction logs a command that executes the failed test alone with the same seed.
+func (spec *Spec) logReproduction(tb testing.TB) {
+	spec.testingTB.Helper()
+	tb.Helper()
+	if !tb.Failed() {
+		return
+	}
+	if _, ok := tb.(*testing.B); ok {
+		return
+	}
+	log(tb, `reproduce with:`, reproductionCommand(spec.seed, tb.Name(), spec.list()[0].pkgPath))
+}
+
+// reproductionCommand formats a copy-pasteable go test command,
+// where the run pattern matches exactly the test with the given name.
+func reproductionCommand(seed int64, testName, pkgPath string) string {
+	var elems []string
+	for _, name := range strings.Split(testName, `/`) {
+		elems = append(elems, `^`+regexp.QuoteMeta(name)+`$`)
+	}
+	pattern := strings.Join(elems, `/`)
+	pattern = `'` + strings.Replace(pattern, `'`, `'\''`, -1) + `'`
+	return fmt.Sprintf(`%s=%d go test -run %s %s`, EnvKeySeed, seed, pattern, pkgPath)
+}
@@ -0,0 +1,47 @@
+package testcase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal"
+)
+
+func TestReproductionCommand(t *testing.T) {
+	assert.Must(t).Equal(
+		`TESTCASE_SEED=42 go test -run '^TestX$/^#Add$/^when_a_\+_b$/^it'\''s_ok$' github.com/foo/bar`,
+		reproductionCommand(42, `TestX/#Add/when_a_+_b/it's_ok`, `github.com/foo/bar`),
+	)
+}
+
+func TestSpec_logReproduction(t *testing.T) {
+	SetEnv(t, EnvKeySeed, `42`)
+	stub := &internal.StubTB{StubName: `TestFoo`}
+	s := NewSpec(stub)
+	s.Test(`failing`, func(t *T) { t.Fail() })
+	stub.Finish()
+	logs := strings.Join(stub.Logs, "\n")
+	assert.Must(t).True(stub.IsFailed)
+	assert.Must(t).Contain(logs, `TESTCASE_SEED=42 go test -run '^TestFoo$' github.com/adamluzsi/testcase`)
+
+	stub = &internal.StubTB{StubName: `TestFoo`}
+	s = NewSpec(stub)
+	s.Test(`passing`, func(t *T) {})
+	stub.Finish()
+	assert.Must(t).True(!strings.Contains(strings.Join(stub.Logs, "\n"), `go test -run`))
+}
+
+func TestSpec_testSeed(t *testing.T) {
+	SetEnv(t, EnvKeySeed, `42`)
+	seedOf := func(name string) int {
+		stub := &internal.StubTB{StubName: name}
+		s := NewSpec(stub)
+		var seed int
+		s.Test(`test`, func(t *T) { seed = t.Random.Int() })
+		stub.Finish()
+		return seed
+	}
+	assert.Must(t).Equal(seedOf(`TestFoo`), seedOf(`TestFoo`))
+	assert.Must(t).NotEqual(seedOf(`TestFoo`), seedOf(`TestBar`))
+}
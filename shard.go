@@ -0,0 +1,139 @@
+package testcase
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adamluzsi/testcase/internal"
+)
+
+const shardSkipMessage = `other shard`
+
+// isInShard tells whether the test belongs to the shard of the current test run.
+// Without a shard configured, every test belongs to the current test run.
+func (spec *Spec) isInShard() bool {
+	spec.testingTB.Helper()
+	settings, ok := getShardSettings()
+	if !ok {
+		return true
+	}
+	return settings.shardOf(spec.id) == settings.index
+}
+
+type shardSettings struct {
+	// index is the zero based index of the current shard
+	index int
+	total int
+	// assigned holds the shard of the tests which have a recorded duration in the timing file.
+	assigned map[string]int
+}
+
+// shardOf assigns the test to a shard.
+// Tests with recorded duration are assigned to balance the total duration of the shards,
+// and the rest of the tests are assigned based on the hash of their id.
+// Both assignment is deterministic, so every test lands on exactly one shard.
+func (s shardSettings) shardOf(id string) int {
+	if shard, ok := s.assigned[id]; ok {
+		return shard
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+	return int(h.Sum32() % uint32(s.total))
+}
+
+// assignShardsByDuration distributes the tests between the shards by always assigning
+// the longest remaining test to the shard with the least total duration.
+func assignShardsByDuration(total int, durations map[string]time.Duration) map[string]int {
+	ids := make([]string, 0, len(durations))
+	for id := range durations {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if durations[ids[i]] != durations[ids[j]] {
+			return durations[ids[i]] > durations[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+	var (
+		loads    = make([]time.Duration, total)
+		assigned = make(map[string]int, len(ids))
+	)
+	for _, id := range ids {
+		shard := 0
+		for i := range loads {
+			if loads[i] < loads[shard] {
+				shard = i
+			}
+		}
+		loads[shard] += durations[id]
+		assigned[id] = shard
+	}
+	return assigned
+}
+
+var (
+	shardSettingsCache struct {
+		init     sync.Once
+		settings shardSettings
+		ok       bool
+	}
+	_ = internal.RegisterCacheFlush(func() {
+		shardSettingsCache.init = sync.Once{}
+	})
+)
+
+func getShardSettings() (shardSettings, bool) {
+	shardSettingsCache.init.Do(func() {
+		shardSettingsCache.settings, shardSettingsCache.ok = getShardSettingsFromENV()
+	})
+	return shardSettingsCache.settings, shardSettingsCache.ok
+}
+
+func getShardSettingsFromENV() (shardSettings, bool) {
+	raw, ok := os.LookupEnv(EnvKeyShard)
+	if !ok || raw == `` {
+		return shardSettings{}, false
+	}
+	parts := strings.Split(raw, `/`)
+	if len(parts) != 2 {
+		panic(fmt.Sprintf(`invalid %s value, i/n format expected: %s`, EnvKeyShard, raw))
+	}
+	index, errIndex := strconv.Atoi(strings.TrimSpace(parts[0]))
+	total, errTotal := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if errIndex != nil || errTotal != nil || total < 1 || index < 1 || total < index {
+		panic(fmt.Sprintf(`invalid %s value, i/n format expected where 1 <= i <= n: %s`, EnvKeyShard, raw))
+	}
+	settings := shardSettings{index: index - 1, total: total}
+	if path, ok := os.LookupEnv(EnvKeyShardTimings); ok && path != `` {
+		settings.assigned = assignShardsByDuration(total, readShardTimings(path))
+	}
+	return settings, true
+}
+
+// readShardTimings reads the test durations from an ordering history file,
+// since sharding depends on the durations that the history based orderers record.
+// A missing or invalid timing file is not an error, the sharding falls back to hashing.
+func readShardTimings(path string) map[string]time.Duration {
+	durations := make(map[string]time.Duration)
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		return durations
+	}
+	var records map[string]orderingHistoryRecord
+	if err := json.Unmarshal(bs, &records); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "testcase: unable to read the shard timings from %s: %s\n", path, err.Error())
+		return durations
+	}
+	for id, r := range records {
+		durations[id] = r.Duration
+	}
+	return durations
+}
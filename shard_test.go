@@ -0,0 +1,122 @@
+package testcase
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal"
+)
+
+func TestGetShardSettingsFromENV(t *testing.T) {
+	t.Run(`when the shard is not set, sharding is disabled`, func(t *testing.T) {
+		UnsetEnv(t, EnvKeyShard)
+		_, ok := getShardSettingsFromENV()
+		assert.Must(t).False(ok)
+	})
+	t.Run(`when the shard is set, the shard index is parsed`, func(t *testing.T) {
+		SetEnv(t, EnvKeyShard, `2/3`)
+		UnsetEnv(t, EnvKeyShardTimings)
+		settings, ok := getShardSettingsFromENV()
+		assert.Must(t).True(ok)
+		assert.Must(t).Equal(1, settings.index)
+		assert.Must(t).Equal(3, settings.total)
+	})
+	for _, invalid := range []string{`3`, `0/3`, `4/3`, `a/b`, `1/0`} {
+		invalid := invalid
+		t.Run(`when the shard is invalid, it panics: `+invalid, func(t *testing.T) {
+			SetEnv(t, EnvKeyShard, invalid)
+			assert.Must(t).Panic(func() { getShardSettingsFromENV() })
+		})
+	}
+	t.Run(`when a timing file is set, the recorded tests are balanced by duration`, func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), `timings.json`)
+		assert.Must(t).Nil(ioutil.WriteFile(path, []byte(`{
+			"a": {"duration": 4000000000},
+			"b": {"duration": 3000000000},
+			"c": {"duration": 2000000000},
+			"d": {"duration": 2000000000}
+		}`), 0644))
+		SetEnv(t, EnvKeyShard, `1/2`)
+		SetEnv(t, EnvKeyShardTimings, path)
+		settings, ok := getShardSettingsFromENV()
+		assert.Must(t).True(ok)
+		assert.Must(t).Equal(map[string]int{`a`: 0, `b`: 1, `c`: 1, `d`: 0}, settings.assigned)
+	})
+}
+
+func TestAssignShardsByDuration(t *testing.T) {
+	assigned := assignShardsByDuration(3, map[string]time.Duration{
+		`a`: 5 * time.Second,
+		`b`: 4 * time.Second,
+		`c`: 3 * time.Second,
+		`d`: 3 * time.Second,
+		`e`: 2 * time.Second,
+		`f`: 1 * time.Second,
+	})
+	loads := make([]time.Duration, 3)
+	for id, shard := range assigned {
+		loads[shard] += map[string]time.Duration{`a`: 5, `b`: 4, `c`: 3, `d`: 3, `e`: 2, `f`: 1}[id] * time.Second
+	}
+	assert.Must(t).Equal([]time.Duration{6 * time.Second, 6 * time.Second, 6 * time.Second}, loads)
+}
+
+func TestSpec_shard(t *testing.T) {
+	run := func(t *testing.T, shard string) (ran []string, skipped []string) {
+		SetEnv(t, EnvKeyShard, shard)
+		SetEnv(t, EnvKeyOrdering, string(OrderingAsDefined))
+		internal.SetupCacheFlush(t)
+
+		stub := &internal.StubTB{StubName: t.Name()}
+		s := NewSpec(stubRunner{StubTB: stub})
+		for _, name := range []string{`a`, `b`, `c`, `d`, `e`, `f`, `g`, `h`} {
+			name := name
+			s.Test(name, func(t *T) { ran = append(ran, name) })
+		}
+		stub.Finish()
+		for _, child := range s.children {
+			if status, _, _ := child.result.get(); status == testStatusSkipped {
+				skipped = append(skipped, child.description)
+			}
+		}
+		return ran, skipped
+	}
+
+	ran1, skipped1 := run(t, `1/2`)
+	ran2, skipped2 := run(t, `2/2`)
+	assert.Must(t).NotEmpty(ran1)
+	assert.Must(t).NotEmpty(ran2)
+	assert.Must(t).ContainExactly([]string{`a`, `b`, `c`, `d`, `e`, `f`, `g`, `h`}, append(ran1, ran2...))
+	assert.Must(t).ContainExactly(ran1, skipped2)
+	assert.Must(t).ContainExactly(ran2, skipped1)
+
+	again, _ := run(t, `1/2`)
+	assert.Must(t).Equal(ran1, again)
+}
+
+func TestSpec_shard_withTimings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), `timings.json`)
+	assert.Must(t).Nil(ioutil.WriteFile(path, []byte(`{
+		"TestSubject/a": {"duration": 4000000000},
+		"TestSubject/b": {"duration": 3000000000},
+		"TestSubject/c": {"duration": 2000000000},
+		"TestSubject/d": {"duration": 2000000000},
+		"TestOther/a":   {"duration": 1}
+	}`), 0644))
+	SetEnv(t, EnvKeyShard, `1/2`)
+	SetEnv(t, EnvKeyShardTimings, path)
+	SetEnv(t, EnvKeyOrdering, string(OrderingAsDefined))
+	internal.SetupCacheFlush(t)
+
+	var ran []string
+	stub := &internal.StubTB{StubName: `TestSubject`}
+	s := NewSpec(stubRunner{StubTB: stub})
+	for _, name := range []string{`a`, `b`, `c`, `d`} {
+		name := name
+		s.Test(name, func(t *T) { ran = append(ran, name) })
+	}
+	stub.Finish()
+	assert.Must(t).Equal([]string{`a`, `d`}, ran)
+}
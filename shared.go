@@ -0,0 +1,128 @@
+package testcase
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/adamluzsi/testcase/internal"
+)
+
+const (
+	sharedVarInitFailed      = `shared variable %s failed to initialize in an earlier test`
+	sharedVarInitFailedInRun = `shared variable %s failed to initialize`
+)
+
+// sharedVar is the state of a variable defined with LetShared.
+// It is initialized once by the first test that uses it,
+// and the rest of the tests in the testing context receive the same value.
+type sharedVar struct {
+	name string
+	init func(tb testing.TB) interface{}
+	// tb is the testing.TB of the root spec, which outlives every test that uses the shared value.
+	tb    testing.TB
+	mutex sync.Mutex
+	// initialized tells if the value is ready,
+	// and failed tells if the initialization had been interrupted by a test failure.
+	initialized bool
+	failed      bool
+	value       interface{}
+	teardown    internal.Teardown
+}
+
+func (sv *sharedVar) get(t *T) interface{} {
+	t.TB.Helper()
+	sv.mutex.Lock()
+	defer sv.mutex.Unlock()
+	if sv.initialized {
+		return sv.value
+	}
+	if sv.failed {
+		t.Fatalf(sharedVarInitFailed, sv.name)
+	}
+	defer func() {
+		// a skipped initialization is attempted again by the next test
+		if sv.initialized || t.Skipped() {
+			return
+		}
+		sv.failed = true
+		t.Errorf(sharedVarInitFailedInRun, sv.name)
+	}()
+	sv.value = sv.init(sharedTB{TB: sv.tb, init: t, teardown: &sv.teardown})
+	sv.initialized = true
+	return sv.value
+}
+
+// close tears down the shared value, after every test of the testing context is finished.
+func (sv *sharedVar) close() {
+	sv.mutex.Lock()
+	defer sv.mutex.Unlock()
+	sv.teardown.Finish()
+	sv.initialized = false
+	sv.failed = false
+	sv.value = nil
+}
+
+// sharedTB is the testing.TB of the shared variable.
+// The failures and skips are reported to the test that initializes the value,
+// since they must be called from the goroutine of that test,
+// while the logs belong to the root spec, which outlives every test that uses the shared value,
+// and the cleanups are deferred until the end of the testing context.
+type sharedTB struct {
+	testing.TB
+	init     *T
+	teardown *internal.Teardown
+}
+
+func (tb sharedTB) Cleanup(fn func()) {
+	tb.teardown.Defer(fn)
+}
+
+func (tb sharedTB) Fail() {
+	tb.init.Fail()
+}
+
+func (tb sharedTB) FailNow() {
+	tb.init.FailNow()
+}
+
+func (tb sharedTB) Failed() bool {
+	return tb.init.Failed()
+}
+
+func (tb sharedTB) Error(args ...interface{}) {
+	tb.init.TB.Helper()
+	tb.init.Error(args...)
+}
+
+func (tb sharedTB) Errorf(format string, args ...interface{}) {
+	tb.init.TB.Helper()
+	tb.init.Errorf(format, args...)
+}
+
+func (tb sharedTB) Fatal(args ...interface{}) {
+	tb.init.TB.Helper()
+	tb.init.Fatal(args...)
+}
+
+func (tb sharedTB) Fatalf(format string, args ...interface{}) {
+	tb.init.TB.Helper()
+	tb.init.Fatalf(format, args...)
+}
+
+func (tb sharedTB) Skip(args ...interface{}) {
+	tb.init.TB.Helper()
+	tb.init.Skip(args...)
+}
+
+func (tb sharedTB) Skipf(format string, args ...interface{}) {
+	tb.init.TB.Helper()
+	tb.init.Skipf(format, args...)
+}
+
+func (tb sharedTB) SkipNow() {
+	tb.init.SkipNow()
+}
+
+func (tb sharedTB) Skipped() bool {
+	return tb.init.Skipped()
+}
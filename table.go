@@ -0,0 +1,83 @@
+package testcase
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/adamluzsi/testcase/internal"
+)
+
+// Table creates a testing context for each row of a table,
+// so edge cases that only differ in their input values don't have to be written one by one.
+// The rows must be a slice or an array, and the block is called once for each row with the row's own sub spec.
+//
+// The context of a row is named after the row.
+// When the row implements fmt.Stringer, its String method is used,
+// else when the row is a struct with a string Name field, the Name field is used.
+// Otherwise the row is named after its index.
+//
+// The exported fields of a struct row tagged with `testcase:"<var name>"` are bound to the row's context
+// as variables with the given name, so they are accessible with Var.Get or T.I.
+// Each test receives a deep copy of the row values,
+// thus mutating a value in one test will not affect the other tests, not even in parallel execution.
+// The row received by the block is the original row, and meant to be used for describing the context.
+//
+// The SpecOption-s are applied to the table's context, so the table can be a testing Group,
+// or the table's tests can be parallel, tagged and so on, just like with Context.
+func (spec *Spec) Table(desc string, rows interface{}, blk func(s *Spec, row interface{}), opts ...SpecOption) {
+	spec.testingTB.Helper()
+	rv := reflect.ValueOf(rows)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		spec.testingTB.Fatalf(`Table rows must be a slice or an array, but got %T`, rows)
+		return
+	}
+	spec.Context(desc, func(s *Spec) {
+		for i := 0; i < rv.Len(); i++ {
+			row := rv.Index(i).Interface()
+			s.Context(tableRowName(i, row), func(s *Spec) {
+				letTableRowValues(s, row)
+				blk(s, row)
+			})
+		}
+	}, opts...)
+}
+
+func tableRowName(index int, row interface{}) string {
+	if row, ok := row.(fmt.Stringer); ok {
+		return row.String()
+	}
+	rv := reflect.ValueOf(row)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Struct {
+		if name := rv.FieldByName(`Name`); name.IsValid() && name.Kind() == reflect.String && name.String() != `` {
+			return name.String()
+		}
+	}
+	return fmt.Sprintf(`#%d`, index)
+}
+
+const tableVarTag = `testcase`
+
+func letTableRowValues(s *Spec, row interface{}) {
+	s.testingTB.Helper()
+	rv := reflect.ValueOf(row)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return
+	}
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		varName, ok := rt.Field(i).Tag.Lookup(tableVarTag)
+		if !ok || rt.Field(i).PkgPath != `` {
+			continue
+		}
+		value := rv.Field(i).Interface()
+		s.Let(varName, func(t *T) interface{} {
+			return internal.DeepCopy(value)
+		})
+	}
+}
@@ -1,14 +1,18 @@
 package testcase
 
 import (
+	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"unicode"
 )
 
 type tagSettings struct {
-	Include map[string]struct{}
-	Exclude map[string]struct{}
+	Include tagExpr
+	Exclude tagExpr
 }
 
 const (
@@ -16,38 +20,330 @@ const (
 	envKeyTagExcludeList = `TESTCASE_TAG_EXCLUDE`
 )
 
-func getTagSettings() tagSettings {
-	var settings = tagSettings{
-		Include: map[string]struct{}{},
-		Exclude: map[string]struct{}{},
-	}
-
-	if rawList, ok := os.LookupEnv(envKeyTagIncludeList); ok {
-		for _, rawTag := range strings.Split(rawList, `,`) {
-			tag := strings.TrimSpace(rawTag)
-			settings.Include[tag] = struct{}{}
+func getTagSettings() (tagSettings, error) {
+	var settings tagSettings
+	if raw, ok := os.LookupEnv(envKeyTagIncludeList); ok && strings.TrimSpace(raw) != `` {
+		expr, err := parseTagExpr(raw)
+		if err != nil {
+			return settings, fmt.Errorf(`%s: %w`, envKeyTagIncludeList, err)
 		}
+		settings.Include = expr
 	}
-
-	if rawList, ok := os.LookupEnv(envKeyTagExcludeList); ok {
-		for _, rawTag := range strings.Split(rawList, `,`) {
-			tag := strings.TrimSpace(rawTag)
-			settings.Exclude[tag] = struct{}{}
+	if raw, ok := os.LookupEnv(envKeyTagExcludeList); ok && strings.TrimSpace(raw) != `` {
+		expr, err := parseTagExpr(raw)
+		if err != nil {
+			return settings, fmt.Errorf(`%s: %w`, envKeyTagExcludeList, err)
 		}
+		settings.Exclude = expr
 	}
-
-	return settings
+	return settings, nil
 }
 
 var (
 	tagSettingsSetup sync.Once
 	tagSettingsCache tagSettings
+	tagSettingsErr   error
 )
 
-func getCachedTagSettings() tagSettings {
+func getCachedTagSettings() (tagSettings, error) {
 	tagSettingsSetup.Do(func() {
-		tagSettingsCache = getTagSettings()
+		tagSettingsCache, tagSettingsErr = getTagSettings()
 	})
 
-	return tagSettingsCache
+	return tagSettingsCache, tagSettingsErr
+}
+
+//-------------------------------------------------- Tag Expression --------------------------------------------------//
+
+// tagExpr is a boolean expression evaluated against the tag set of a test.
+//
+// The expression supports the following operators, from the lowest to the highest precedence:
+//  - `,` for or, to keep supporting the comma separated tag lists
+//  - `||` for or
+//  - `&&` for and
+//  - `!` for not
+// Parentheses can be used for grouping.
+//
+// example:
+// 	integration && !slow
+// 	(db || cache) && !flaky
+type tagExpr interface {
+	eval(tags map[string]struct{}) bool
+}
+
+type tagExprTag string
+
+func (e tagExprTag) eval(tags map[string]struct{}) bool {
+	_, ok := tags[string(e)]
+	return ok
+}
+
+type tagExprNot struct{ expr tagExpr }
+
+func (e tagExprNot) eval(tags map[string]struct{}) bool { return !e.expr.eval(tags) }
+
+type tagExprAnd struct{ left, right tagExpr }
+
+func (e tagExprAnd) eval(tags map[string]struct{}) bool {
+	return e.left.eval(tags) && e.right.eval(tags)
+}
+
+type tagExprOr struct{ left, right tagExpr }
+
+func (e tagExprOr) eval(tags map[string]struct{}) bool {
+	return e.left.eval(tags) || e.right.eval(tags)
+}
+
+// TagExprError is returned when a tag expression is malformed.
+type TagExprError struct {
+	Expr string
+	// Position is the zero based byte offset of the offending part of the expression.
+	Position int
+	Reason   string
+}
+
+func (err *TagExprError) Error() string {
+	return fmt.Sprintf("invalid tag expression at position %d: %s\n\t%s\n\t%s^",
+		err.Position, err.Reason, err.Expr, strings.Repeat(` `, err.Position))
+}
+
+func parseTagExpr(expr string) (tagExpr, error) {
+	p := &tagExprParser{expr: expr}
+	p.next()
+	e, err := p.parseList()
+	if err != nil {
+		return nil, err
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.token.kind != tagTokenEOF {
+		return nil, p.errorf(`unexpected %s`, p.token)
+	}
+	return e, nil
+}
+
+type tagTokenKind int
+
+const (
+	tagTokenEOF tagTokenKind = iota
+	tagTokenTag
+	tagTokenComma
+	tagTokenOr
+	tagTokenAnd
+	tagTokenNot
+	tagTokenLParen
+	tagTokenRParen
+)
+
+type tagToken struct {
+	kind  tagTokenKind
+	value string
+	pos   int
+}
+
+func (t tagToken) String() string {
+	if t.kind == tagTokenEOF {
+		return `end of expression`
+	}
+	return strconv.Quote(t.value)
+}
+
+type tagExprParser struct {
+	expr  string
+	pos   int
+	token tagToken
+	err   error
+}
+
+func (p *tagExprParser) errorf(format string, args ...interface{}) error {
+	if p.err != nil {
+		return p.err
+	}
+	return &TagExprError{Expr: p.expr, Position: p.token.pos, Reason: fmt.Sprintf(format, args...)}
+}
+
+// next reads the next token of the expression.
+func (p *tagExprParser) next() {
+	for p.pos < len(p.expr) && unicode.IsSpace(rune(p.expr[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if len(p.expr) <= p.pos {
+		p.token = tagToken{kind: tagTokenEOF, pos: start}
+		return
+	}
+	for _, op := range []struct {
+		value string
+		kind  tagTokenKind
+	}{
+		{value: `||`, kind: tagTokenOr},
+		{value: `&&`, kind: tagTokenAnd},
+		{value: `,`, kind: tagTokenComma},
+		{value: `!`, kind: tagTokenNot},
+		{value: `(`, kind: tagTokenLParen},
+		{value: `)`, kind: tagTokenRParen},
+	} {
+		if strings.HasPrefix(p.expr[p.pos:], op.value) {
+			p.pos += len(op.value)
+			p.token = tagToken{kind: op.kind, value: op.value, pos: start}
+			return
+		}
+	}
+	for p.pos < len(p.expr) && !isTagExprSpecialChar(p.expr[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		// a lone & or | character
+		p.err = &TagExprError{Expr: p.expr, Position: start, Reason: fmt.Sprintf(`unexpected %q`, p.expr[start:start+1])}
+		p.pos++
+		p.token = tagToken{kind: tagTokenEOF, pos: start}
+		return
+	}
+	p.token = tagToken{kind: tagTokenTag, value: p.expr[start:p.pos], pos: start}
+}
+
+func isTagExprSpecialChar(c byte) bool {
+	return strings.IndexByte(`,|&!() `, c) != -1 || unicode.IsSpace(rune(c))
+}
+
+func (p *tagExprParser) parseList() (tagExpr, error) {
+	left, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	for p.token.kind == tagTokenComma {
+		p.next()
+		right, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		left = tagExprOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *tagExprParser) parseOr() (tagExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.token.kind == tagTokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = tagExprOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *tagExprParser) parseAnd() (tagExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.token.kind == tagTokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = tagExprAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *tagExprParser) parseUnary() (tagExpr, error) {
+	switch p.token.kind {
+	case tagTokenNot:
+		p.next()
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return tagExprNot{expr: e}, nil
+
+	case tagTokenLParen:
+		p.next()
+		e, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		if p.token.kind != tagTokenRParen {
+			return nil, p.errorf(`expected ")" but got %s`, p.token)
+		}
+		p.next()
+		return e, nil
+
+	case tagTokenTag:
+		tag := tagExprTag(p.token.value)
+		p.next()
+		return tag, nil
+
+	default:
+		return nil, p.errorf(`expected a tag but got %s`, p.token)
+	}
+}
+
+//------------------------------------------------------ Dry Run ------------------------------------------------------//
+
+func isTagDryRun() bool {
+	raw, ok := os.LookupEnv(EnvKeyTagDryRun)
+	if !ok || raw == `` {
+		return false
+	}
+	dryRun, err := strconv.ParseBool(raw)
+	return err != nil || dryRun
+}
+
+// printTagDryRunSummary lists the tests selected by the tag expressions at the end of the specification.
+func (spec *Spec) printTagDryRunSummary() {
+	spec.testingTB.Helper()
+	if spec.parent != nil {
+		return
+	}
+	tests := spec.selectedTests.flush()
+	if len(tests) == 0 {
+		return
+	}
+	lines := []interface{}{fmt.Sprintf("%d test(s) selected:\n", len(tests))}
+	for _, st := range tests {
+		line := "  " + st.desc
+		if 0 < len(st.tags) {
+			line += ` [` + strings.Join(st.tags, `, `) + `]`
+		}
+		lines = append(lines, line+"\n")
+	}
+	log(spec.testingTB, lines...)
+}
+
+type selectedTests struct {
+	mutex sync.Mutex
+	tests []selectedTest
+}
+
+type selectedTest struct {
+	desc string
+	tags []string
+}
+
+func (sts *selectedTests) add(desc string, tagSet map[string]struct{}) {
+	sts.mutex.Lock()
+	defer sts.mutex.Unlock()
+	var tags []string
+	for tag := range tagSet {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	sts.tests = append(sts.tests, selectedTest{desc: desc, tags: tags})
+}
+
+func (sts *selectedTests) flush() []selectedTest {
+	sts.mutex.Lock()
+	defer sts.mutex.Unlock()
+	tests := sts.tests
+	sts.tests = nil
+	return tests
 }
@@ -1,12 +1,15 @@
 package testcase
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 
 	"github.com/adamluzsi/testcase/assert"
+	"github.com/adamluzsi/testcase/internal"
 )
 
 func TestSpec_Tag_withEnvVariable(t *testing.T) {
@@ -85,6 +88,150 @@ func TestSpec_Tag_withEnvVariable(t *testing.T) {
 	})
 }
 
+func TestParseTagExpr(t *testing.T) {
+	tagSet := func(tags ...string) map[string]struct{} {
+		set := make(map[string]struct{})
+		for _, tag := range tags {
+			set[tag] = struct{}{}
+		}
+		return set
+	}
+
+	for _, tc := range []struct {
+		expr     string
+		tags     map[string]struct{}
+		expected bool
+	}{
+		{expr: `a`, tags: tagSet(`a`), expected: true},
+		{expr: `a`, tags: tagSet(`b`), expected: false},
+		{expr: `a,b`, tags: tagSet(`b`), expected: true},
+		{expr: `a, b`, tags: tagSet(`c`), expected: false},
+		{expr: `a && b`, tags: tagSet(`a`), expected: false},
+		{expr: `a && b`, tags: tagSet(`a`, `b`), expected: true},
+		{expr: `a || b`, tags: tagSet(`b`), expected: true},
+		{expr: `!a`, tags: tagSet(`b`), expected: true},
+		{expr: `!a`, tags: tagSet(`a`), expected: false},
+		{expr: `!!a`, tags: tagSet(`a`), expected: true},
+		{expr: `integration && !slow`, tags: tagSet(`integration`), expected: true},
+		{expr: `integration && !slow`, tags: tagSet(`integration`, `slow`), expected: false},
+		{expr: `a || b && c`, tags: tagSet(`a`), expected: true},
+		{expr: `(a || b) && c`, tags: tagSet(`a`), expected: false},
+		{expr: `(db || cache) && !flaky`, tags: tagSet(`cache`), expected: true},
+		{expr: `(db || cache) && !flaky`, tags: tagSet(`db`, `flaky`), expected: false},
+		{expr: `a && b, c`, tags: tagSet(`c`), expected: true},
+		{expr: `E2E-test.v2`, tags: tagSet(`E2E-test.v2`), expected: true},
+	} {
+		expr, err := parseTagExpr(tc.expr)
+		assert.Must(t).Nil(err, tc.expr)
+		assert.Must(t).Equal(tc.expected, expr.eval(tc.tags), tc.expr)
+	}
+}
+
+func TestParseTagExpr_malformed(t *testing.T) {
+	for _, tc := range []struct {
+		expr     string
+		position int
+		reason   string
+	}{
+		{expr: `a &&`, position: 4, reason: `expected a tag but got end of expression`},
+		{expr: `(a || b`, position: 7, reason: `expected ")" but got end of expression`},
+		{expr: `(a && b))`, position: 8, reason: `unexpected ")"`},
+		{expr: `a & b`, position: 2, reason: `unexpected "&"`},
+		{expr: `a b`, position: 2, reason: `unexpected "b"`},
+		{expr: `&& a`, position: 0, reason: `expected a tag but got "&&"`},
+		{expr: `a,,b`, position: 2, reason: `expected a tag but got ","`},
+	} {
+		_, err := parseTagExpr(tc.expr)
+		var exprErr *TagExprError
+		assert.Must(t).True(errors.As(err, &exprErr), tc.expr)
+		assert.Must(t).Equal(tc.position, exprErr.Position, tc.expr)
+		assert.Must(t).Equal(tc.reason, exprErr.Reason, tc.expr)
+	}
+
+	_, err := parseTagExpr(`(a && b))`)
+	assert.Must(t).Equal("invalid tag expression at position 8: unexpected \")\"\n\t(a && b))\n\t        ^", err.Error())
+}
+
+func TestSpec_Tag_withExpression(t *testing.T) {
+	defer resetTagEnvVariables()()
+	ranWithTags := func(tags ...string) bool {
+		resetTagCache()
+		defer resetTagCache()
+		stub := &internal.StubTB{}
+		s := NewSpec(stub)
+		s.Tag(tags...)
+		var ran bool
+		s.Test(``, func(t *T) { ran = true })
+		stub.Finish()
+		return ran
+	}
+
+	t.Run(`when the include expression matches the spec tags, the test runs`, func(t *testing.T) {
+		defer resetTagEnvVariables()()
+		os.Setenv(envKeyTagIncludeList, `the-tag && !slow`)
+		assert.Must(t).True(ranWithTags(`the-tag`))
+	})
+
+	t.Run(`when the include expression doesn't match the spec tags, the test doesn't run`, func(t *testing.T) {
+		defer resetTagEnvVariables()()
+		os.Setenv(envKeyTagIncludeList, `the-tag && !slow`)
+		assert.Must(t).False(ranWithTags(`the-tag`, `slow`))
+	})
+
+	t.Run(`when the exclude expression matches the spec tags, the test doesn't run`, func(t *testing.T) {
+		defer resetTagEnvVariables()()
+		os.Setenv(envKeyTagExcludeList, `(db || cache) && slow`)
+		assert.Must(t).False(ranWithTags(`cache`, `slow`))
+	})
+
+	t.Run(`when the exclude expression doesn't match the spec tags, the test runs`, func(t *testing.T) {
+		defer resetTagEnvVariables()()
+		os.Setenv(envKeyTagExcludeList, `(db || cache) && slow`)
+		assert.Must(t).True(ranWithTags(`cache`))
+	})
+
+	t.Run(`when the expression is malformed, the spec fails with the parse error`, func(t *testing.T) {
+		defer resetTagEnvVariables()()
+		resetTagCache()
+		defer resetTagCache()
+		os.Setenv(envKeyTagIncludeList, `a &&`)
+		stub := &internal.StubTB{}
+		s := NewSpec(stub)
+		internal.Recover(func() { s.Test(``, func(t *T) {}) })
+		assert.Must(t).True(stub.IsFailed)
+		assert.Must(t).Contain(strings.Join(stub.Logs, "\n"), `TESTCASE_TAG_INCLUDE: invalid tag expression at position 4`)
+	})
+}
+
+func TestSpec_Tag_dryRun(t *testing.T) {
+	defer resetTagEnvVariables()()
+	resetTagCache()
+	defer resetTagCache()
+	os.Setenv(envKeyTagIncludeList, `integration && !slow`)
+	SetEnv(t, EnvKeyTagDryRun, `true`)
+
+	stub := &internal.StubTB{}
+	s := NewSpec(stub)
+	var ran bool
+	s.Describe(`#Store`, func(s *Spec) {
+		s.Tag(`integration`)
+		s.Test(`fast`, func(t *T) { ran = true })
+		s.Context(``, func(s *Spec) {
+			s.Tag(`slow`)
+			s.Test(`slow`, func(t *T) { ran = true })
+		})
+	})
+	s.Test(`unit`, func(t *T) { ran = true })
+	stub.Finish()
+
+	assert.Must(t).False(ran)
+	logs := strings.Join(stub.Logs, "\n")
+	assert.Must(t).Contain(logs, "1 test(s) selected:\n")
+	assert.Must(t).Contain(logs, "  describe #Store fast [integration]\n")
+	assert.Must(t).NotContain(logs, `slow [`)
+	assert.Must(t).NotContain(logs, `unit`)
+}
+
 func resetEnv(key string) func() {
 	ogValue, ok := os.LookupEnv(key)
 
@@ -0,0 +1,163 @@
+// Package testcasevet provides a static analyzer that reports the misuses of the testcase package.
+//
+// Most of the reported mistakes would be caught only at runtime by testcase,
+// or they wouldn't be caught at all.
+// The analyzer can be used with go vet:
+//
+//	go install github.com/adamluzsi/testcase/testcasevet/cmd/testcasevet@latest
+//	go vet -vettool=$(which testcasevet) ./...
+package testcasevet
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const doc = `report the misuses of the testcase package
+
+The testcase analyzer checks for:
+  - Spec setup calls (Let, hooks, Parallel, ...) after a sub-context or test is defined in the same Spec
+  - LetValue with a value of a kind that LetValue rejects at runtime (slice, map, pointer, struct, ...)
+  - loop variables captured by a test block, which runs only after the loop is finished
+  - duplicate Var names in the package
+  - Var.Get on a Var with OnLet that is never bound to a Spec
+  - Fatal, FailNow, Skip and T.Must assertions from a goroutine started by the test`
+
+// Analyzer reports the misuses of the testcase package.
+var Analyzer = &analysis.Analyzer{
+	Name:     `testcase`,
+	Doc:      doc,
+	URL:      `https://pkg.go.dev/github.com/adamluzsi/testcase/testcasevet`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+const testcasePkgPath = `github.com/adamluzsi/testcase`
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	if !importsTestcase(pass.Pkg) {
+		return nil, nil
+	}
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	checkImmutableSpec(pass, insp)
+	checkLetValue(pass, insp)
+	checkLoopVariables(pass, insp)
+	checkVars(pass, insp)
+	checkGoroutines(pass, insp)
+	return nil, nil
+}
+
+func importsTestcase(pkg *types.Package) bool {
+	if pkg.Path() == testcasePkgPath {
+		return true
+	}
+	for _, imp := range pkg.Imports() {
+		if imp.Path() == testcasePkgPath {
+			return true
+		}
+	}
+	return false
+}
+
+// call is a call to a function or method of the testcase package.
+type call struct {
+	expr *ast.CallExpr
+	// recv is the name of the receiver type for method calls, e.g.: Spec, Var or T.
+	recv string
+	name string
+}
+
+func testcaseCall(info *types.Info, expr *ast.CallExpr) (call, bool) {
+	fn, ok := typeutil.Callee(info, expr).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != testcasePkgPath {
+		return call{}, false
+	}
+	c := call{expr: expr, name: fn.Name()}
+	if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+		c.recv = typeName(recv.Type())
+	}
+	return c, true
+}
+
+// spec returns the expression of the *Spec that the call affects.
+func (c call) spec() ast.Expr {
+	switch {
+	case c.recv == `Spec`:
+		if sel, ok := ast.Unparen(c.expr.Fun).(*ast.SelectorExpr); ok {
+			return sel.X
+		}
+	case c.recv == `Var`, c.recv == `` && (c.name == `Let` || c.name == `LetValue`):
+		if 0 < len(c.expr.Args) {
+			return c.expr.Args[0]
+		}
+	}
+	return nil
+}
+
+// typeName returns the name of the testcase type, or an empty string for other types.
+// VarOf is reported as Var, since the typed variables are checked the same way as the untyped ones.
+func typeName(typ types.Type) string {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != testcasePkgPath {
+		return ``
+	}
+	if named.Obj().Name() == `VarOf` {
+		return `Var`
+	}
+	return named.Obj().Name()
+}
+
+// objectOf returns the variable that the expression refers to, or nil when the expression is not a simple identifier.
+func objectOf(info *types.Info, expr ast.Expr) types.Object {
+	id, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	return info.ObjectOf(id)
+}
+
+// importName returns the name that the file uses to refer to the imported package.
+func importName(file *ast.File, pkg *types.Package, path string) (string, bool) {
+	if pkg.Path() == path {
+		return ``, true
+	}
+	for _, imp := range file.Imports {
+		if imp.Path.Value != `"`+path+`"` {
+			continue
+		}
+		if imp.Name == nil {
+			for _, p := range pkg.Imports() {
+				if p.Path() == path {
+					return p.Name() + `.`, true
+				}
+			}
+			return ``, false
+		}
+		switch imp.Name.Name {
+		case `_`:
+			return ``, false
+		case `.`:
+			return ``, true
+		default:
+			return imp.Name.Name + `.`, true
+		}
+	}
+	return ``, false
+}
+
+func enclosingFile(pass *analysis.Pass, node ast.Node) *ast.File {
+	for _, file := range pass.Files {
+		if file.FileStart <= node.Pos() && node.Pos() < file.FileEnd {
+			return file
+		}
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package testcasevet_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/adamluzsi/testcase/testcasevet"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), testcasevet.Analyzer,
+		`immutable`, `letvalue`, `loopvar`, `goroutine`)
+	analysistest.Run(t, analysistest.TestData(), testcasevet.Analyzer, `vars`)
+}
@@ -0,0 +1,14 @@
+// Command testcasevet reports the misuses of the testcase package.
+//
+// usage:
+//
+//	go vet -vettool=$(which testcasevet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/unitchecker"
+
+	"github.com/adamluzsi/testcase/testcasevet"
+)
+
+func main() { unitchecker.Main(testcasevet.Analyzer) }
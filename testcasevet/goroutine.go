@@ -0,0 +1,89 @@
+package testcasevet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// failNowMethods are the methods of testcase.T that stop the test with runtime.Goexit,
+// and their replacement that only marks the test as failed.
+var failNowMethods = map[string]string{
+	`Fatal`:   `Error`,
+	`Fatalf`:  `Errorf`,
+	`FailNow`: `Fail`,
+	`Skip`:    ``,
+	`Skipf`:   ``,
+	`SkipNow`: ``,
+}
+
+// checkGoroutines reports the calls that stop the test from a goroutine started by the test,
+// since runtime.Goexit would only stop the goroutine and not the test itself.
+func checkGoroutines(pass *analysis.Pass, insp *inspector.Inspector) {
+	insp.Preorder([]ast.Node{(*ast.GoStmt)(nil)}, func(node ast.Node) {
+		lit, ok := node.(*ast.GoStmt).Call.Fun.(*ast.FuncLit)
+		if !ok {
+			return
+		}
+		ast.Inspect(lit.Body, func(node ast.Node) bool {
+			if _, ok := node.(*ast.GoStmt); ok {
+				return false // reported on its own
+			}
+			if sel, ok := node.(*ast.SelectorExpr); ok {
+				checkGoroutineSelector(pass, sel)
+			}
+			return true
+		})
+	})
+}
+
+func checkGoroutineSelector(pass *analysis.Pass, sel *ast.SelectorExpr) {
+	selection, ok := pass.TypesInfo.Selections[sel]
+	if !ok || typeName(selection.Recv()) != `T` {
+		return
+	}
+	switch selection.Kind() {
+	case types.MethodVal:
+		replacement, ok := failNowMethods[sel.Sel.Name]
+		if !ok {
+			return
+		}
+		diagnostic := analysis.Diagnostic{
+			Pos:     sel.Pos(),
+			End:     sel.End(),
+			Message: fmt.Sprintf(`%s.%s called from a goroutine, which would only stop the goroutine and not the test`, exprString(sel.X), sel.Sel.Name),
+		}
+		if replacement != `` {
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{renameFix(sel.Sel, replacement)}
+		}
+		pass.Report(diagnostic)
+
+	case types.FieldVal:
+		if sel.Sel.Name != `Must` {
+			return
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:            sel.Pos(),
+			End:            sel.End(),
+			Message:        fmt.Sprintf(`%s.Must used from a goroutine, where a failed assertion would only stop the goroutine and not the test`, exprString(sel.X)),
+			SuggestedFixes: []analysis.SuggestedFix{renameFix(sel.Sel, `Should`)},
+		})
+	}
+}
+
+func renameFix(id *ast.Ident, name string) analysis.SuggestedFix {
+	return analysis.SuggestedFix{
+		Message:   fmt.Sprintf(`use %s instead`, name),
+		TextEdits: []analysis.TextEdit{{Pos: id.Pos(), End: id.End(), NewText: []byte(name)}},
+	}
+}
+
+func exprString(expr ast.Expr) string {
+	if id, ok := ast.Unparen(expr).(*ast.Ident); ok {
+		return id.Name
+	}
+	return `T`
+}
@@ -0,0 +1,185 @@
+package testcasevet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// specContextMethods are the Spec methods which define a sub-context or a test,
+// after which the Spec becomes immutable.
+var specContextMethods = map[string]struct{}{
+	`Context`:  {},
+	`Describe`: {},
+	`When`:     {},
+	`And`:      {},
+	`Then`:     {},
+	`Test`:     {},
+	`Property`: {},
+	`Table`:    {},
+	`Todo`:     {},
+}
+
+// specSetupMethods are the Spec methods which configure the Spec,
+// and which testcase rejects after the Spec became immutable.
+var specSetupMethods = map[string]struct{}{
+	`Let`:           {},
+	`LetValue`:      {},
+	`LetShared`:     {},
+	`Before`:        {},
+	`After`:         {},
+	`Around`:        {},
+	`BeforeAll`:     {},
+	`AfterAll`:      {},
+	`AroundAll`:     {},
+	`Skip`:          {},
+	`Parallel`:      {},
+	`Sequential`:    {},
+	`NoSideEffect`:  {},
+	`HasSideEffect`: {},
+	`SkipBenchmark`: {},
+	`Focus`:         {},
+	`Pending`:       {},
+}
+
+// varSetupMethods are the Var methods which bind the Var to a Spec.
+var varSetupMethods = map[string]struct{}{
+	`Let`:          {},
+	`LetValue`:     {},
+	`LetShared`:    {},
+	`LetSuper`:     {},
+	`Bind`:         {},
+	`EagerLoading`: {},
+}
+
+func isContextCall(c call) bool {
+	_, ok := specContextMethods[c.name]
+	return c.recv == `Spec` && ok
+}
+
+func isSetupCall(c call) bool {
+	switch c.recv {
+	case `Spec`:
+		_, ok := specSetupMethods[c.name]
+		return ok
+	case `Var`:
+		_, ok := varSetupMethods[c.name]
+		return ok
+	case ``:
+		return c.name == `Let` || c.name == `LetValue`
+	default:
+		return false
+	}
+}
+
+// checkImmutableSpec reports the Spec setup calls that come after a sub-context or a test definition,
+// which testcase would reject at runtime with a Fatal.
+func checkImmutableSpec(pass *analysis.Pass, insp *inspector.Inspector) {
+	insp.Preorder([]ast.Node{(*ast.BlockStmt)(nil)}, func(node ast.Node) {
+		type contextStmt struct {
+			stmt ast.Stmt
+			call call
+		}
+		var (
+			block     = node.(*ast.BlockStmt)
+			immutable = make(map[types.Object]contextStmt)
+		)
+		for _, stmt := range block.List {
+			c, ok := stmtCall(pass, stmt)
+			if !ok {
+				continue
+			}
+			spec := objectOf(pass.TypesInfo, c.spec())
+			if spec == nil {
+				continue
+			}
+			if isContextCall(c) {
+				if _, ok := immutable[spec]; !ok {
+					immutable[spec] = contextStmt{stmt: stmt, call: c}
+				}
+				continue
+			}
+			first, ok := immutable[spec]
+			if !ok || !isSetupCall(c) {
+				continue
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos: c.expr.Pos(),
+				End: c.expr.End(),
+				Message: fmt.Sprintf(`%s is used on %s after %s.%s, but a Spec can't be configured after a sub-context or test is defined in it`,
+					c.name, spec.Name(), spec.Name(), first.call.name),
+				SuggestedFixes: moveStmtFix(pass, stmt, first.stmt),
+			})
+		}
+	})
+}
+
+// stmtCall returns the testcase call that the statement consists of.
+func stmtCall(pass *analysis.Pass, stmt ast.Stmt) (call, bool) {
+	var expr ast.Expr
+	switch stmt := stmt.(type) {
+	case *ast.ExprStmt:
+		expr = stmt.X
+	case *ast.AssignStmt:
+		if len(stmt.Rhs) != 1 {
+			return call{}, false
+		}
+		expr = stmt.Rhs[0]
+	case *ast.DeclStmt:
+		decl, ok := stmt.Decl.(*ast.GenDecl)
+		if !ok || len(decl.Specs) != 1 {
+			return call{}, false
+		}
+		vs, ok := decl.Specs[0].(*ast.ValueSpec)
+		if !ok || len(vs.Values) != 1 {
+			return call{}, false
+		}
+		expr = vs.Values[0]
+	}
+	callExpr, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return call{}, false
+	}
+	return testcaseCall(pass.TypesInfo, callExpr)
+}
+
+// moveStmtFix moves the statement before the target statement, along with its lines.
+func moveStmtFix(pass *analysis.Pass, stmt, target ast.Stmt) []analysis.SuggestedFix {
+	file := pass.Fset.File(stmt.Pos())
+	src, err := pass.ReadFile(file.Name())
+	if err != nil {
+		return nil
+	}
+	var (
+		from = lineStart(file, stmt.Pos())
+		to   = nextLineStart(file, stmt.End())
+		at   = lineStart(file, target.Pos())
+	)
+	text := src[file.Offset(from):file.Offset(to)]
+	if len(text) == 0 || text[len(text)-1] != '\n' {
+		return nil
+	}
+	return []analysis.SuggestedFix{{
+		Message: `move it before the first sub-context or test`,
+		TextEdits: []analysis.TextEdit{
+			{Pos: at, End: at, NewText: text},
+			{Pos: from, End: to},
+		},
+	}}
+}
+
+func lineStart(file *token.File, pos token.Pos) token.Pos {
+	return file.LineStart(file.Line(pos))
+}
+
+func nextLineStart(file *token.File, pos token.Pos) token.Pos {
+	line := file.Line(pos)
+	if file.LineCount() <= line {
+		return token.Pos(file.Base() + file.Size())
+	}
+	return file.LineStart(line + 1)
+}
@@ -0,0 +1,148 @@
+package testcasevet
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// checkLetValue reports the LetValue calls with a value that LetValue rejects at runtime.
+// LetValue accepts only values of a basic kind, like numbers and strings,
+// since it can't guarantee that the mutations on other kind of values don't leak to other tests.
+func checkLetValue(pass *analysis.Pass, insp *inspector.Inspector) {
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(node ast.Node) {
+		c, ok := testcaseCall(pass.TypesInfo, node.(*ast.CallExpr))
+		if !ok || c.name != `LetValue` {
+			return
+		}
+		var valueIndex int
+		switch c.recv {
+		case `Spec`, `Var`:
+			valueIndex = 1
+		case ``:
+			valueIndex = 2
+		default:
+			return
+		}
+		if len(c.expr.Args) <= valueIndex {
+			return
+		}
+		value := c.expr.Args[valueIndex]
+		typ := pass.TypesInfo.TypeOf(value)
+		if typ == nil || isAcceptedLetValueType(typ) {
+			return
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:            value.Pos(),
+			End:            value.End(),
+			Message:        fmt.Sprintf(`LetValue can't be used with a value of type %s, since mutations on it would leak to other tests; use Let instead`, types.TypeString(typ, packageName(pass.Pkg))),
+			SuggestedFixes: letValueFix(pass, c, valueIndex),
+		})
+	})
+}
+
+func isAcceptedLetValueType(typ types.Type) bool {
+	if types.IsInterface(typ) {
+		// the dynamic type is only known at runtime
+		return true
+	}
+	if _, ok := typ.(*types.TypeParam); ok {
+		return true
+	}
+	basic, ok := typ.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+	return basic.Info()&(types.IsBoolean|types.IsNumeric|types.IsString) != 0
+}
+
+// letValueFix replaces the LetValue call with a Let call that constructs the value in each test.
+// The fix is only offered for composite literals, since for other expressions the value would be shared between the tests.
+func letValueFix(pass *analysis.Pass, c call, valueIndex int) []analysis.SuggestedFix {
+	value := c.expr.Args[valueIndex]
+	if _, ok := ast.Unparen(value).(*ast.CompositeLit); !ok {
+		return nil
+	}
+	file := enclosingFile(pass, c.expr)
+	if file == nil {
+		return nil
+	}
+	qual, ok := importName(file, pass.Pkg, testcasePkgPath)
+	if !ok {
+		return nil
+	}
+	resultType := `interface{}`
+	if c.recv != `Spec` {
+		sig, ok := pass.TypesInfo.TypeOf(c.expr.Fun).(*types.Signature)
+		if !ok {
+			return nil
+		}
+		resultType, ok = typeString(file, pass.Pkg, sig.Params().At(valueIndex).Type())
+		if !ok {
+			return nil
+		}
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, pass.Fset, value); err != nil {
+		return nil
+	}
+	letFn := fmt.Sprintf(`func(*%sT) %s { return %s }`, qual, resultType, buf.String())
+	name := funcName(c.expr.Fun)
+	if name == nil {
+		return nil
+	}
+	return []analysis.SuggestedFix{{
+		Message: `use Let to construct the value in each test`,
+		TextEdits: []analysis.TextEdit{
+			{Pos: name.Pos(), End: name.End(), NewText: []byte(`Let`)},
+			{Pos: value.Pos(), End: value.End(), NewText: []byte(letFn)},
+		},
+	}}
+}
+
+// funcName returns the identifier of the called function or method.
+func funcName(fun ast.Expr) *ast.Ident {
+	switch fun := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		return fun
+	case *ast.SelectorExpr:
+		return fun.Sel
+	case *ast.IndexExpr:
+		return funcName(fun.X)
+	case *ast.IndexListExpr:
+		return funcName(fun.X)
+	default:
+		return nil
+	}
+}
+
+// packageName qualifies the types of other packages with the package name.
+func packageName(pkg *types.Package) types.Qualifier {
+	return func(p *types.Package) string {
+		if p == pkg {
+			return ``
+		}
+		return p.Name()
+	}
+}
+
+// typeString formats the type with the package names that the file uses.
+func typeString(file *ast.File, pkg *types.Package, typ types.Type) (string, bool) {
+	ok := true
+	s := types.TypeString(typ, func(p *types.Package) string {
+		name, found := importName(file, pkg, p.Path())
+		if !found {
+			ok = false
+		}
+		if name == `` {
+			return ``
+		}
+		return name[:len(name)-1]
+	})
+	return s, ok
+}
@@ -0,0 +1,156 @@
+package testcasevet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// deferredBlockCalls are the testcase calls whose function arguments are executed only when the tests run,
+// which happens after the whole specification is defined.
+var deferredBlockCalls = map[call]struct{}{
+	{recv: `Spec`, name: `Test`}:      {},
+	{recv: `Spec`, name: `Then`}:      {},
+	{recv: `Spec`, name: `Property`}:  {},
+	{recv: `Spec`, name: `Let`}:       {},
+	{recv: `Spec`, name: `LetShared`}: {},
+	{recv: `Spec`, name: `Before`}:    {},
+	{recv: `Spec`, name: `After`}:     {},
+	{recv: `Spec`, name: `Around`}:    {},
+	{recv: `Spec`, name: `BeforeAll`}: {},
+	{recv: `Spec`, name: `AfterAll`}:  {},
+	{recv: `Spec`, name: `AroundAll`}: {},
+	{recv: `Var`, name: `Let`}:        {},
+	{recv: `Var`, name: `LetSuper`}:   {},
+	{recv: `Var`, name: `LetShared`}:  {},
+	{recv: ``, name: `Let`}:           {},
+}
+
+// checkLoopVariables reports the loop variables that are captured by a test block or hook,
+// when the loop variable is shared between the iterations, which is the case before Go 1.22.
+// Since testcase runs the tests only after the specification is defined,
+// every test would see the value of the last iteration.
+func checkLoopVariables(pass *analysis.Pass, insp *inspector.Inspector) {
+	insp.Preorder([]ast.Node{(*ast.RangeStmt)(nil), (*ast.ForStmt)(nil)}, func(node ast.Node) {
+		file := enclosingFile(pass, node)
+		if file == nil || !hasSharedLoopVariables(pass.TypesInfo.FileVersions[file]) {
+			return
+		}
+		var (
+			vars []*ast.Ident
+			body *ast.BlockStmt
+		)
+		switch loop := node.(type) {
+		case *ast.RangeStmt:
+			if loop.Tok == token.DEFINE {
+				vars = identifiers(loop.Key, loop.Value)
+			}
+			body = loop.Body
+		case *ast.ForStmt:
+			if init, ok := loop.Init.(*ast.AssignStmt); ok && init.Tok == token.DEFINE {
+				vars = identifiers(init.Lhs...)
+			}
+			body = loop.Body
+		}
+		loopVars := make(map[types.Object]struct{})
+		for _, id := range vars {
+			if obj := pass.TypesInfo.Defs[id]; obj != nil {
+				loopVars[obj] = struct{}{}
+			}
+		}
+		if len(loopVars) == 0 {
+			return
+		}
+		ast.Inspect(body, func(node ast.Node) bool {
+			callExpr, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			c, ok := testcaseCall(pass.TypesInfo, callExpr)
+			if !ok {
+				return true
+			}
+			if _, ok := deferredBlockCalls[call{recv: c.recv, name: c.name}]; !ok {
+				return true
+			}
+			for _, arg := range callExpr.Args {
+				if lit, ok := arg.(*ast.FuncLit); ok {
+					reportCapturedLoopVariables(pass, body, lit, loopVars)
+				}
+			}
+			return false
+		})
+	})
+}
+
+func hasSharedLoopVariables(fileVersion string) bool {
+	return version.IsValid(fileVersion) && version.Compare(fileVersion, `go1.22`) < 0
+}
+
+func identifiers(exprs ...ast.Expr) []*ast.Ident {
+	var ids []*ast.Ident
+	for _, expr := range exprs {
+		if id, ok := expr.(*ast.Ident); ok && id.Name != `_` {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func reportCapturedLoopVariables(pass *analysis.Pass, body *ast.BlockStmt, lit *ast.FuncLit, loopVars map[types.Object]struct{}) {
+	reported := make(map[types.Object]struct{})
+	ast.Inspect(lit.Body, func(node ast.Node) bool {
+		id, ok := node.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		obj := pass.TypesInfo.Uses[id]
+		if _, ok := loopVars[obj]; !ok {
+			return true
+		}
+		if _, ok := reported[obj]; ok {
+			return true
+		}
+		reported[obj] = struct{}{}
+		pass.Report(analysis.Diagnostic{
+			Pos:            id.Pos(),
+			End:            id.End(),
+			Message:        fmt.Sprintf(`loop variable %s captured by a test block, which runs only after the loop is finished`, id.Name),
+			SuggestedFixes: copyLoopVariableFix(pass, body, id.Name),
+		})
+		return true
+	})
+}
+
+// copyLoopVariableFix declares a copy of the loop variable for each iteration at the beginning of the loop body.
+func copyLoopVariableFix(pass *analysis.Pass, body *ast.BlockStmt, name string) []analysis.SuggestedFix {
+	if len(body.List) == 0 {
+		return nil
+	}
+	var (
+		first = body.List[0]
+		file  = pass.Fset.File(first.Pos())
+		at    = lineStart(file, first.Pos())
+	)
+	if at <= body.Lbrace {
+		return nil
+	}
+	src, err := pass.ReadFile(file.Name())
+	if err != nil {
+		return nil
+	}
+	indent := src[file.Offset(at):file.Offset(first.Pos())]
+	return []analysis.SuggestedFix{{
+		Message: fmt.Sprintf(`copy %s for each iteration`, name),
+		TextEdits: []analysis.TextEdit{{
+			Pos:     at,
+			End:     at,
+			NewText: []byte(fmt.Sprintf("%s%s := %s\n", indent, name, name)),
+		}},
+	}}
+}
@@ -0,0 +1,10 @@
+package assert
+
+type Asserter struct{}
+
+func (Asserter) True(bool) {}
+
+type It struct {
+	Must   Asserter
+	Should Asserter
+}
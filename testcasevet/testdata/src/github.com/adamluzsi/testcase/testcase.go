@@ -0,0 +1,56 @@
+// Package testcase is a stub of the testcase package for the analyzer tests.
+package testcase
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase/assert"
+)
+
+type Spec struct{}
+
+func NewSpec(tb testing.TB) *Spec { return &Spec{} }
+
+func (spec *Spec) Context(desc string, blk func(s *Spec))  {}
+func (spec *Spec) Describe(desc string, blk func(s *Spec)) {}
+func (spec *Spec) When(desc string, blk func(s *Spec))     {}
+func (spec *Spec) And(desc string, blk func(s *Spec))      {}
+func (spec *Spec) Then(desc string, blk func(t *T))        {}
+func (spec *Spec) Test(desc string, blk func(t *T))        {}
+
+func (spec *Spec) Let(name string, blk func(t *T) interface{}) Var { return Var{} }
+func (spec *Spec) LetValue(name string, value interface{}) Var     { return Var{} }
+func (spec *Spec) Before(blk func(t *T))                           {}
+func (spec *Spec) Parallel()                                       {}
+
+type T struct {
+	testing.TB
+	assert.It
+}
+
+type Var struct {
+	Name  string
+	Init  func(t *T) interface{}
+	OnLet func(s *Spec)
+}
+
+func (v Var) Get(t *T) interface{}                        { return nil }
+func (v Var) Set(t *T, value interface{})                 {}
+func (v Var) Let(s *Spec, blk func(t *T) interface{}) Var { return v }
+func (v Var) LetValue(s *Spec, value interface{}) Var     { return v }
+func (v Var) Bind(s *Spec) Var                            { return v }
+
+type VarOf[V any] struct {
+	Name  string
+	Init  func(t *T) V
+	OnLet func(s *Spec)
+}
+
+func (v VarOf[V]) Get(t *T) V                             { var zero V; return zero }
+func (v VarOf[V]) Set(t *T, value V)                      {}
+func (v VarOf[V]) Let(s *Spec, blk func(t *T) V) VarOf[V] { return v }
+func (v VarOf[V]) LetValue(s *Spec, value V) VarOf[V]     { return v }
+func (v VarOf[V]) Bind(s *Spec) VarOf[V]                  { return v }
+
+func Let[V any](s *Spec, name string, blk func(t *T) V) VarOf[V] { return VarOf[V]{} }
+func LetValue[V any](s *Spec, name string, value V) VarOf[V]     { return VarOf[V]{} }
@@ -0,0 +1,22 @@
+package goroutine
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+)
+
+func Test(t *testing.T) {
+	s := testcase.NewSpec(t)
+	s.Test(``, func(t *testcase.T) {
+		go func() {
+			t.Fatal(`boom`)    // want `t.Fatal called from a goroutine, which would only stop the goroutine and not the test`
+			t.FailNow()        // want `t.FailNow called from a goroutine`
+			t.SkipNow()        // want `t.SkipNow called from a goroutine`
+			t.Must.True(false) // want `t.Must used from a goroutine, where a failed assertion would only stop the goroutine and not the test`
+			t.Should.True(false)
+			t.Error(`boom`)
+		}()
+		t.Must.True(true)
+	})
+}
@@ -0,0 +1,31 @@
+package immutable
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+)
+
+func Test(t *testing.T) {
+	s := testcase.NewSpec(t)
+	s.Before(func(t *testcase.T) {})
+
+	s.When(`something`, func(s *testcase.Spec) {
+		s.Let(`ok`, func(t *testcase.T) interface{} { return 42 })
+
+		s.Then(`it works`, func(t *testcase.T) {})
+	})
+
+	s.Let(`late`, func(t *testcase.T) interface{} { return 42 }) // want `Let is used on s after s.When, but a Spec can't be configured after a sub-context or test is defined in it`
+}
+
+func TestTyped(t *testing.T) {
+	s := testcase.NewSpec(t)
+	v := testcase.VarOf[int]{Name: `v`}
+
+	s.Test(`test`, func(t *testcase.T) {})
+
+	value := testcase.LetValue(s, `value`, 42) // want `LetValue is used on s after s.Test`
+	v.Bind(s)                                  // want `Bind is used on s after s.Test`
+	_ = value
+}
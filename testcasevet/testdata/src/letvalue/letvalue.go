@@ -0,0 +1,31 @@
+package letvalue
+
+import (
+	"testing"
+
+	tc "github.com/adamluzsi/testcase"
+)
+
+type Entity struct{ ID int }
+
+type Name string
+
+func Test(t *testing.T) {
+	s := tc.NewSpec(t)
+
+	s.LetValue(`int`, 42)
+	s.LetValue(`string`, Name(`name`))
+	s.LetValue(`any`, interface{}(nil))
+	s.LetValue(`slice`, []int{1, 2, 3})  // want `LetValue can't be used with a value of type \[\]int, since mutations on it would leak to other tests; use Let instead`
+	s.LetValue(`struct`, Entity{ID: 42}) // want `LetValue can't be used with a value of type Entity`
+
+	entity := &Entity{}
+	s.LetValue(`pointer`, entity) // want `LetValue can't be used with a value of type \*Entity`
+
+	tc.LetValue(s, `int`, 42)
+	tc.LetValue(s, `map`, map[string]int{`a`: 1})    // want `LetValue can't be used with a value of type map\[string\]int`
+	tc.LetValue[[]Entity](s, `entities`, []Entity{}) // want `LetValue can't be used with a value of type \[\]Entity`
+
+	v := tc.VarOf[[]Name]{Name: `names`}
+	v.LetValue(s, []Name{`a`}) // want `LetValue can't be used with a value of type \[\]Name`
+}
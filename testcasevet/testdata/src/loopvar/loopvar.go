@@ -0,0 +1,30 @@
+//go:build go1.21
+
+package loopvar
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+)
+
+func Test(t *testing.T) {
+	s := testcase.NewSpec(t)
+	s.Parallel()
+
+	for _, tc := range []string{`a`, `b`} {
+		s.Context(tc, func(s *testcase.Spec) {
+			s.Before(func(t *testcase.T) {
+				_ = tc // want `loop variable tc captured by a test block, which runs only after the loop is finished`
+			})
+		})
+	}
+
+	for i := 0; i < 2; i++ {
+		n := i
+		s.Test(`test`, func(t *testcase.T) {
+			_ = n
+			_ = i // want `loop variable i captured by a test block`
+		})
+	}
+}
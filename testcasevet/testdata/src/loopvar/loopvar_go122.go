@@ -0,0 +1,16 @@
+//go:build go1.22
+
+package loopvar
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+)
+
+func TestPerIterationLoopVariables(t *testing.T) {
+	s := testcase.NewSpec(t)
+	for _, tc := range []string{`a`, `b`} {
+		s.Test(tc, func(t *testcase.T) { _ = tc })
+	}
+}
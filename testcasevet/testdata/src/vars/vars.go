@@ -0,0 +1,11 @@
+package vars
+
+import "github.com/adamluzsi/testcase"
+
+const name = `value`
+
+var (
+	A = testcase.Var{Name: name}
+	B = testcase.VarOf[string]{Name: `value`} // want `Var name "value" is already used by A at .*vars.go:8:6, and the two Vars would access the same variable`
+	C = testcase.Var{Name: `c`}
+)
@@ -0,0 +1,33 @@
+package vars
+
+import (
+	"testing"
+
+	"github.com/adamluzsi/testcase"
+)
+
+var (
+	unbound = testcase.VarOf[int]{
+		Name:  `unbound`,
+		OnLet: func(s *testcase.Spec) {},
+	}
+	bound = testcase.Var{
+		Name:  `bound`,
+		OnLet: func(s *testcase.Spec) {},
+	}
+	Exported = testcase.VarOf[int]{
+		Name:  `exported`,
+		OnLet: func(s *testcase.Spec) {},
+	}
+)
+
+func Test(t *testing.T) {
+	s := testcase.NewSpec(t)
+	bound.Bind(s)
+	s.Test(``, func(t *testcase.T) {
+		_ = unbound.Get(t) // want `unbound has OnLet, but it is never bound to a Spec with Let, LetValue or Bind, thus Get will fail`
+		_ = bound.Get(t)
+		_ = Exported.Get(t)
+		t.Log(unbound.Name)
+	})
+}
@@ -0,0 +1,168 @@
+package testcasevet
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// packageVar is a Var declared at package level with a composite literal.
+type packageVar struct {
+	obj   types.Object
+	lit   *ast.CompositeLit
+	name  string
+	onLet bool
+}
+
+// checkVars reports the package level Var declarations with a name that is already used by another Var,
+// and the Var.Get calls on a Var with OnLet, that is never bound to a Spec.
+func checkVars(pass *analysis.Pass, insp *inspector.Inspector) {
+	vars := packageVars(pass)
+	checkDuplicateVarNames(pass, vars)
+	checkUnboundOnLetVars(pass, insp, vars)
+}
+
+func packageVars(pass *analysis.Pass) []packageVar {
+	var vars []packageVar
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				if len(vs.Names) != len(vs.Values) {
+					continue
+				}
+				for i, value := range vs.Values {
+					lit, ok := ast.Unparen(value).(*ast.CompositeLit)
+					if !ok || typeName(pass.TypesInfo.TypeOf(lit)) != `Var` {
+						continue
+					}
+					obj := pass.TypesInfo.Defs[vs.Names[i]]
+					if obj == nil {
+						continue
+					}
+					pv := packageVar{obj: obj, lit: lit}
+					for _, elt := range lit.Elts {
+						kv, ok := elt.(*ast.KeyValueExpr)
+						if !ok {
+							continue
+						}
+						key, ok := kv.Key.(*ast.Ident)
+						if !ok {
+							continue
+						}
+						switch key.Name {
+						case `Name`:
+							if tv := pass.TypesInfo.Types[kv.Value]; tv.Value != nil && tv.Value.Kind() == constant.String {
+								pv.name = constant.StringVal(tv.Value)
+							}
+						case `OnLet`:
+							pv.onLet = true
+						}
+					}
+					vars = append(vars, pv)
+				}
+			}
+		}
+	}
+	return vars
+}
+
+func checkDuplicateVarNames(pass *analysis.Pass, vars []packageVar) {
+	declared := make(map[string]packageVar)
+	for _, v := range vars {
+		if v.name == `` {
+			continue
+		}
+		other, ok := declared[v.name]
+		if !ok {
+			declared[v.name] = v
+			continue
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos: v.lit.Pos(),
+			End: v.lit.End(),
+			Message: fmt.Sprintf(`Var name %q is already used by %s at %s, and the two Vars would access the same variable`,
+				v.name, other.obj.Name(), pass.Fset.Position(other.lit.Pos())),
+		})
+	}
+}
+
+// checkUnboundOnLetVars reports the Get calls on unexported Vars with OnLet,
+// when the Var is never bound to a Spec in the package.
+// Only Vars declared in test files are checked,
+// since a Var from a non-test file is usually bound by the tests, which are not always part of the analysed package.
+func checkUnboundOnLetVars(pass *analysis.Pass, insp *inspector.Inspector, vars []packageVar) {
+	candidates := make(map[types.Object]struct{})
+	for _, v := range vars {
+		if v.onLet && !v.obj.Exported() &&
+			strings.HasSuffix(pass.Fset.Position(v.lit.Pos()).Filename, `_test.go`) {
+			candidates[v.obj] = struct{}{}
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+	var gets []*ast.SelectorExpr
+	insp.WithStack([]ast.Node{(*ast.Ident)(nil)}, func(node ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		obj := pass.TypesInfo.Uses[node.(*ast.Ident)]
+		if _, ok := candidates[obj]; !ok {
+			return true
+		}
+		if sel, ok := accessorCall(stack); ok {
+			if sel.Sel.Name == `Get` {
+				gets = append(gets, sel)
+			}
+			return true
+		}
+		// any other use, like Var.Let or passing it to a function, might bind the Var to a Spec
+		delete(candidates, obj)
+		return true
+	})
+	for _, sel := range gets {
+		obj := pass.TypesInfo.Uses[ast.Unparen(sel.X).(*ast.Ident)]
+		if _, ok := candidates[obj]; !ok {
+			continue
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:     sel.Pos(),
+			End:     sel.End(),
+			Message: fmt.Sprintf(`%s has OnLet, but it is never bound to a Spec with Let, LetValue or Bind, thus Get will fail`, obj.Name()),
+		})
+	}
+}
+
+// accessorCall tells if the identifier on the top of the stack is the receiver of a Get or Set call,
+// or a field access like Var.Name.
+func accessorCall(stack []ast.Node) (*ast.SelectorExpr, bool) {
+	if len(stack) < 2 {
+		return nil, false
+	}
+	sel, ok := stack[len(stack)-2].(*ast.SelectorExpr)
+	if !ok || sel.X != stack[len(stack)-1] {
+		return nil, false
+	}
+	switch sel.Sel.Name {
+	case `Name`, `Init`, `Before`, `OnLet`:
+		return sel, true
+	case `Get`, `Set`:
+		if 3 <= len(stack) {
+			if c, ok := stack[len(stack)-3].(*ast.CallExpr); ok && c.Fun == sel {
+				return sel, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,117 @@
+package testcase
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+func (spec *Spec) lookupTimeout() (time.Duration, bool) {
+	spec.testingTB.Helper()
+	specs := spec.list()
+	// the closest scope's timeout overrides the outer scope's timeout
+	for i := len(specs) - 1; 0 <= i; i-- {
+		if specs[i].timeout != nil {
+			return *specs[i].timeout, true
+		}
+	}
+	return 0, false
+}
+
+// runWithTimeout executes the test block in a separate goroutine,
+// and fails the test from the test's own goroutine when the block doesn't finish within the timeout.
+// The hanging goroutine can't be stopped, thus it is left behind, but the test no longer blocks the whole test run.
+func (spec *Spec) runWithTimeout(t *T, blk func()) {
+	spec.testingTB.Helper()
+	t.TB.Helper()
+	timeout, ok := spec.lookupTimeout()
+	if !ok {
+		blk()
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		blk()
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+	case <-timer.C:
+		t.Fatal(spec.timeoutReport(timeout, t.phase.get(), goroutineDump()))
+	}
+}
+
+func (spec *Spec) timeoutReport(timeout time.Duration, phase string, dump string) string {
+	var msg strings.Builder
+	_, _ = fmt.Fprintf(&msg, "test timed out after %s during %s\n", timeout, phase)
+	if desc := spec.descriptionPath(); desc != `` {
+		_, _ = fmt.Fprintf(&msg, "\n%s\n", desc)
+	}
+	_, _ = fmt.Fprintf(&msg, "\ngoroutine dump at the time of the timeout:\n\n%s", dump)
+	return msg.String()
+}
+
+func goroutineDump() string {
+	buf := make([]byte, 1024*64)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+		buf = make([]byte, len(buf)*2)
+	}
+}
+
+// testPhase describes which part of the test execution is running at the moment.
+// It is read from a different goroutine than the one that executes the test,
+// thus the access is synchronised.
+type testPhase struct {
+	mutex sync.RWMutex
+	desc  string
+	// hook is set while a hook is executed.
+	hook *hook
+}
+
+func (p *testPhase) set(format string, args ...interface{}) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.desc = fmt.Sprintf(format, args...)
+	p.hook = nil
+}
+
+func (p *testPhase) setHook(h hook, scope string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.desc = fmt.Sprintf(`a hook of %s`, scope)
+	p.hook = &h
+}
+
+// hookLocation describes the declaration of the hook that is being executed,
+// or returns an empty string when no hook is executed.
+func (p *testPhase) hookLocation() string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	if p.hook == nil {
+		return ``
+	}
+	return fmt.Sprintf(`%s hook declared at %s`, p.hook.kind, p.hook.location)
+}
+
+func (p *testPhase) get() string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	if p.desc == `` {
+		return `test preparation`
+	}
+	if p.hook != nil {
+		return fmt.Sprintf(`%s (%s hook declared at %s)`, p.desc, p.hook.kind, p.hook.location)
+	}
+	return p.desc
+}
@@ -8,11 +8,15 @@ import (
 
 func newVariables() *variables {
 	return &variables{
-		defs:   make(map[string]letBlock),
-		cache:  make(map[string]interface{}),
-		onLet:  make(map[string]struct{}),
-		locks:  make(map[string]*sync.RWMutex),
-		before: make(map[string]struct{}),
+		defs:      make(map[string]letBlock),
+		locations: make(map[string]string),
+		scopes:    make(map[string]string),
+		cache:     make(map[string]interface{}),
+		onLet:     make(map[string]struct{}),
+		locks:     make(map[string]*sync.RWMutex),
+		before:    make(map[string]struct{}),
+		deps:      make(map[string][]string),
+		chains:    make(map[int64][]string),
 	}
 }
 
@@ -20,16 +24,27 @@ func newVariables() *variables {
 // Using the variables cache within the individual test cases are safe even with *testing#T.Parallel().
 // Different test cases don't share they variables instance.
 type variables struct {
-	mutex  sync.RWMutex
-	defs   map[string]letBlock
-	cache  map[string]interface{}
-	onLet  map[string]struct{}
-	locks  map[string]*sync.RWMutex
-	before map[string]struct{}
+	mutex sync.RWMutex
+	defs  map[string]letBlock
+	// locations holds where the variables were declared with Let.
+	locations map[string]string
+	cache     map[string]interface{}
+	onLet     map[string]struct{}
+	locks     map[string]*sync.RWMutex
+	before    map[string]struct{}
+	// deps holds the variables that the Let block of a variable used during its initialization.
+	deps  map[string][]string
+	inits []string
+	// scopes holds the name of the spec scope that declared the variable with Let.
+	scopes map[string]string
+	// chains are the stacks of the variables which are being initialized by their Let block,
+	// per goroutine, since the variables of a test can be initialized concurrently.
+	chains map[int64][]string
 }
 
 func (v *variables) Knows(varName string) bool {
-	defer v.rLock(varName)()
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
 	if _, found := v.defs[varName]; found {
 		return true
 	}
@@ -45,9 +60,17 @@ func (v *variables) Let(varName string, blk letBlock /* [interface{}] */) {
 }
 
 func (v *variables) let(varName string, blk letBlock /* [interface{}] */) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
 	v.defs[varName] = blk
 }
 
+func (v *variables) def(varName string) letBlock {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	return v.defs[varName]
+}
+
 // Get will return a testcase vs.
 //
 // If there is no such value, then it will panic with a "friendly" message.
@@ -56,14 +79,107 @@ func (v *variables) Get(t *T, varName string) interface{} {
 	if !v.Knows(varName) {
 		t.Fatal(v.fatalMessageFor(varName))
 	}
+	chain := v.letChain()
+	v.addDependency(chain, varName)
+	if !v.cacheHas(varName) {
+		// a variable that is not yet initialized, but already in the chain,
+		// would wait for its own lock, thus instead of hanging, the cycle is reported.
+		for i, name := range chain {
+			if name == varName {
+				t.Fatal(v.cycleMessageFor(append(chain[i:], varName)))
+			}
+		}
+	}
 	defer v.lock(varName)()
 	if !v.cacheHas(varName) {
+		v.addInit(varName)
+		defer v.pushLetChain(varName)()
 		// cacheSet(varName, ...) is protected from concurrent access by lock(varName).
-		v.cacheSet(varName, v.defs[varName](t))
+		v.cacheSet(varName, v.def(varName)(t))
 	}
 	return t.vars.cacheGet(varName)
 }
 
+// letChain returns a copy of the stack of the variables which are being initialized by the current goroutine.
+func (v *variables) letChain() []string {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	if len(v.chains) == 0 {
+		return nil
+	}
+	return append([]string{}, v.chains[currentGoroutineID()]...)
+}
+
+// pushLetChain pushes the variable onto the stack of the variables which are being initialized by the current goroutine,
+// and returns the function that pops it once its Let block is finished.
+func (v *variables) pushLetChain(varName string) func() {
+	id := currentGoroutineID()
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.chains[id] = append(v.chains[id], varName)
+	return func() {
+		v.mutex.Lock()
+		defer v.mutex.Unlock()
+		if chain := v.chains[id][:len(v.chains[id])-1]; 0 < len(chain) {
+			v.chains[id] = chain
+		} else {
+			delete(v.chains, id)
+		}
+	}
+}
+
+func (v *variables) addDependency(chain []string, varName string) {
+	if len(chain) == 0 {
+		return
+	}
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	parent := chain[len(chain)-1]
+	for _, dep := range v.deps[parent] {
+		if dep == varName {
+			return
+		}
+	}
+	v.deps[parent] = append(v.deps[parent], varName)
+}
+
+func (v *variables) addInit(varName string) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.inits = append(v.inits, varName)
+}
+
+func (v *variables) cycleMessageFor(chain []string) string {
+	var msg strings.Builder
+	_, _ = fmt.Fprintf(&msg, "Let variable dependency cycle detected: %s", strings.Join(chain, ` -> `))
+	for _, name := range chain[:len(chain)-1] {
+		_, _ = fmt.Fprintf(&msg, "\n\t%s", v.describe(name))
+	}
+	return msg.String()
+}
+
+// graph describes the variables initialized so far, along with the variables they depend on.
+func (v *variables) graph() string {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	var msg strings.Builder
+	msg.WriteString(`variable dependency graph:`)
+	for _, name := range v.inits {
+		_, _ = fmt.Fprintf(&msg, "\n\t%s", v.describe(name))
+		if deps := v.deps[name]; 0 < len(deps) {
+			_, _ = fmt.Fprintf(&msg, ` -> %s`, strings.Join(deps, `, `))
+		}
+	}
+	return msg.String()
+}
+
+func (v *variables) describe(varName string) string {
+	if loc := v.locations[varName]; loc != `` {
+		return fmt.Sprintf(`%s (%s)`, varName, loc)
+	}
+	return varName
+}
+
 func (v *variables) cacheGet(varName string) interface{} {
 	v.mutex.RLock()
 	defer v.mutex.RUnlock()
@@ -85,7 +201,7 @@ func (v *variables) cacheSet(varName string, data interface{}) {
 
 func (v *variables) Set(varName string, value interface{}) {
 	defer v.lock(varName)()
-	if _, ok := v.defs[varName]; !ok {
+	if !v.Knows(varName) {
 		v.let(varName, func(t *T) interface{} { return value })
 	}
 	v.cacheSet(varName, value)
@@ -95,6 +211,9 @@ func (v *variables) reset() {
 	v.mutex.Lock()
 	defer v.mutex.Unlock()
 	v.cache = make(map[string]interface{})
+	v.deps = make(map[string][]string)
+	v.inits = nil
+	v.chains = make(map[int64][]string)
 }
 
 func (v *variables) fatalMessageFor(varName string) string {
@@ -111,6 +230,8 @@ func (v *variables) fatalMessageFor(varName string) string {
 func (v *variables) merge(oth *variables) {
 	for key, value := range oth.defs {
 		v.defs[key] = value
+		v.locations[key] = oth.locations[key]
+		v.scopes[key] = oth.scopes[key]
 	}
 }
 
@@ -131,12 +252,6 @@ func (v *variables) hasOnLetHookApplied(name string) bool {
 	return ok
 }
 
-func (v *variables) rLock(varName string) func() {
-	m := v.getMutex(varName)
-	m.RLock()
-	return m.RUnlock
-}
-
 func (v *variables) lock(varName string) func() {
 	m := v.getMutex(varName)
 	m.Lock()